@@ -9,21 +9,26 @@ import (
 )
 
 // -- bool Value
-type boolValue bool
+type boolValue struct {
+	value    *bool
+	defValue bool
+}
 
 var _ Value = (*boolValue)(nil)
 var _ Getter = (*boolValue)(nil)
 var _ Typed = (*boolValue)(nil)
 var _ OptionalValue = (*boolValue)(nil)
 var _ BoolFlag = (*boolValue)(nil)
+var _ Cloner = (*boolValue)(nil)
+var _ Resetter = (*boolValue)(nil)
 
 func newBoolValue(val bool, p *bool) *boolValue {
 	*p = val
-	return (*boolValue)(p)
+	return &boolValue{value: p, defValue: val}
 }
 
 func (b *boolValue) Get() interface{} {
-	return bool(*b)
+	return *b.value
 }
 
 func (b *boolValue) Set(val string) error {
@@ -36,7 +41,7 @@ func (b *boolValue) Set(val string) error {
 			return err
 		}
 	}
-	*b = boolValue(v)
+	*b.value = v
 	return nil
 }
 
@@ -44,7 +49,16 @@ func (b *boolValue) Type() string {
 	return "bool"
 }
 
-func (b *boolValue) String() string { return strconv.FormatBool(bool(*b)) }
+func (b *boolValue) Clone() Value {
+	value := *b.value
+	return &boolValue{value: &value, defValue: b.defValue}
+}
+
+func (b *boolValue) Reset() {
+	*b.value = b.defValue
+}
+
+func (b *boolValue) String() string { return strconv.FormatBool(*b.value) }
 
 func (b *boolValue) IsBoolFlag() bool { return true }
 
@@ -11,19 +11,23 @@ import (
 
 // -- boolSlice Value
 type boolSliceValue struct {
-	value   *[]bool
-	changed bool
+	value    *[]bool
+	changed  bool
+	defaults []bool
 }
 
 var _ Value = (*boolSliceValue)(nil)
 var _ Getter = (*boolSliceValue)(nil)
 var _ SliceValue = (*boolSliceValue)(nil)
 var _ Typed = (*boolSliceValue)(nil)
+var _ Cloner = (*boolSliceValue)(nil)
+var _ Resetter = (*boolSliceValue)(nil)
 
 func newBoolSliceValue(val []bool, p *[]bool) *boolSliceValue {
 	bsv := new(boolSliceValue)
 	bsv.value = p
 	*bsv.value = val
+	bsv.defaults = val
 	return bsv
 }
 
@@ -54,6 +58,16 @@ func (s *boolSliceValue) Type() string {
 	return "boolSlice"
 }
 
+func (s *boolSliceValue) Clone() Value {
+	value := append([]bool(nil), *s.value...)
+	return &boolSliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *boolSliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 // String defines a "native" format for this boolean slice flag value.
 func (s *boolSliceValue) String() string {
 	if s.value == nil || *s.value == nil {
@@ -11,19 +11,24 @@ import (
 )
 
 // BytesHex adapts []byte for use as a flag. Value of flag is HEX encoded
-type bytesHexValue []byte
+type bytesHexValue struct {
+	value    *[]byte
+	defValue []byte
+}
 
 var _ Value = (*bytesHexValue)(nil)
 var _ Getter = (*bytesHexValue)(nil)
 var _ Typed = (*bytesHexValue)(nil)
+var _ Cloner = (*bytesHexValue)(nil)
+var _ Resetter = (*bytesHexValue)(nil)
 
 // String implements zflag.Value.
 func (bytesHex *bytesHexValue) String() string {
-	return fmt.Sprintf("%X", *bytesHex)
+	return fmt.Sprintf("%X", *bytesHex.value)
 }
 
 func (bytesHex *bytesHexValue) Get() interface{} {
-	return []byte(*bytesHex)
+	return *bytesHex.value
 }
 
 // Set implements zflag.Value.Set.
@@ -34,7 +39,7 @@ func (bytesHex *bytesHexValue) Set(value string) error {
 		return err
 	}
 
-	*bytesHex = bin
+	*bytesHex.value = bin
 
 	return nil
 }
@@ -44,9 +49,20 @@ func (*bytesHexValue) Type() string {
 	return "bytesHex"
 }
 
+// Clone implements zflag.Cloner.Clone.
+func (bytesHex *bytesHexValue) Clone() Value {
+	value := *bytesHex.value
+	return &bytesHexValue{value: &value, defValue: bytesHex.defValue}
+}
+
+// Reset implements zflag.Resetter.Reset.
+func (bytesHex *bytesHexValue) Reset() {
+	*bytesHex.value = bytesHex.defValue
+}
+
 func newBytesHexValue(val []byte, p *[]byte) *bytesHexValue {
 	*p = val
-	return (*bytesHexValue)(p)
+	return &bytesHexValue{value: p, defValue: val}
 }
 
 // GetBytesHex return the []byte value of a flag with the given name
@@ -96,19 +112,24 @@ func BytesHex(name string, value []byte, usage string, opts ...Opt) *[]byte {
 }
 
 // BytesBase64 adapts []byte for use as a flag. Value of flag is Base64 encoded
-type bytesBase64Value []byte
+type bytesBase64Value struct {
+	value    *[]byte
+	defValue []byte
+}
 
 var _ Value = (*bytesBase64Value)(nil)
 var _ Getter = (*bytesBase64Value)(nil)
 var _ Typed = (*bytesBase64Value)(nil)
+var _ Cloner = (*bytesBase64Value)(nil)
+var _ Resetter = (*bytesBase64Value)(nil)
 
 // String implements zflag.Value.String.
 func (bytesBase64 *bytesBase64Value) String() string {
-	return base64.StdEncoding.EncodeToString(*bytesBase64)
+	return base64.StdEncoding.EncodeToString(*bytesBase64.value)
 }
 
 func (bytesBase64 *bytesBase64Value) Get() interface{} {
-	return []byte(*bytesBase64)
+	return *bytesBase64.value
 }
 
 // Set implements zflag.Value.Set.
@@ -118,7 +139,7 @@ func (bytesBase64 *bytesBase64Value) Set(value string) error {
 		return err
 	}
 
-	*bytesBase64 = bin
+	*bytesBase64.value = bin
 
 	return nil
 }
@@ -128,9 +149,20 @@ func (*bytesBase64Value) Type() string {
 	return "bytesBase64"
 }
 
+// Clone implements zflag.Cloner.Clone.
+func (bytesBase64 *bytesBase64Value) Clone() Value {
+	value := *bytesBase64.value
+	return &bytesBase64Value{value: &value, defValue: bytesBase64.defValue}
+}
+
+// Reset implements zflag.Resetter.Reset.
+func (bytesBase64 *bytesBase64Value) Reset() {
+	*bytesBase64.value = bytesBase64.defValue
+}
+
 func newBytesBase64Value(val []byte, p *[]byte) *bytesBase64Value {
 	*p = val
-	return (*bytesBase64Value)(p)
+	return &bytesBase64Value{value: p, defValue: val}
 }
 
 // GetBytesBase64 return the []byte value of a flag with the given name
@@ -0,0 +1,135 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Clone returns a copy of the FlagSet with the same flags, aliases, flag
+// groups, requirements, validators, providers, normalize function and
+// settings. The values of the flags are copied with Cloner, so the copy
+// shares no storage with fs: setting a flag on one does not change the
+// other. The copy starts with the current values of the flags, but is not
+// parsed and has no flags marked as changed. Clone returns an error if the
+// value of a flag does not implement Cloner, which all values provided by
+// zflag do.
+func (fs *FlagSet) Clone() (*FlagSet, error) {
+	defer fs.rlock()()
+	clone := *fs
+	if fs.mu != nil {
+		clone.mu = new(sync.RWMutex)
+	}
+	clone.parsed = false
+	clone.frozen = false
+	clone.actual = nil
+	clone.orderedActual = nil
+	clone.sortedActual = nil
+	clone.sortedFormal = nil
+	clone.args = nil
+	clone.argsLenAtDash = -1
+	clone.addedGoFlagSets = nil
+	clone.unknownFlags = nil
+	clone.ignoredErrors = nil
+	clone.providers = append([]Provider(nil), fs.providers...)
+	clone.validators = append([]func(*FlagSet) error(nil), fs.validators...)
+	clone.sourcePrecedence = append([]Source(nil), fs.sourcePrecedence...)
+
+	if err := clone.cloneFlags(fs); err != nil {
+		return nil, err
+	}
+
+	return &clone, nil
+}
+
+// cloneFlags sets the flags of fs to copies of the flags of orig, and points
+// its lookup maps, flag groups and requirements to the copies.
+func (fs *FlagSet) cloneFlags(orig *FlagSet) error {
+	flags := make(map[*Flag]*Flag, len(orig.orderedFormal))
+	fs.orderedFormal = make([]*Flag, 0, len(orig.orderedFormal))
+	for _, flag := range orig.orderedFormal {
+		f, err := flag.clone()
+		if err != nil {
+			return err
+		}
+		flags[flag] = f
+		fs.orderedFormal = append(fs.orderedFormal, f)
+	}
+
+	fs.formal = mapNamedFlags(orig.formal, flags)
+	fs.aliases = mapNamedFlags(orig.aliases, flags)
+	fs.shorthands = make(map[rune]*Flag, len(orig.shorthands))
+	for shorthand, flag := range orig.shorthands {
+		fs.shorthands[shorthand] = flags[flag]
+	}
+
+	fs.flagGroups = make([]flagGroup, 0, len(orig.flagGroups))
+	for _, group := range orig.flagGroups {
+		fs.flagGroups = append(fs.flagGroups, flagGroup{kind: group.kind, flags: mapFlags(group.flags, flags)})
+	}
+	fs.requirements = make([]conditionalRequirement, 0, len(orig.requirements))
+	for _, requirement := range orig.requirements {
+		requirement.flags = mapFlags(requirement.flags, flags)
+		fs.requirements = append(fs.requirements, requirement)
+	}
+
+	return nil
+}
+
+// clone returns a copy of the flag with a cloned value and its own copies of
+// the shorthands, aliases, annotations, choices and validators.
+func (f *Flag) clone() (*Flag, error) {
+	var value Value
+	if cloner, ok := f.Value.(Cloner); ok {
+		value = cloner.Clone()
+	} else if goValue, ok := cloneGoFlagValue(f.Value); ok {
+		value = goValue
+	} else {
+		return nil, fmt.Errorf("unable to clone flag %q: value of type %T does not implement Cloner", f.Name, f.Value)
+	}
+
+	clone := *f
+	clone.Value = value
+	clone.Changed = false
+	clone.source = ""
+	clone.occurrences = nil
+	clone.Shorthands = append([]rune(nil), f.Shorthands...)
+	clone.Aliases = append([]string(nil), f.Aliases...)
+	clone.Choices = append([]string(nil), f.Choices...)
+	clone.Validators = append([]func(interface{}) error(nil), f.Validators...)
+	if f.ShorthandsDeprecated != nil {
+		clone.ShorthandsDeprecated = make(map[rune]string, len(f.ShorthandsDeprecated))
+		for shorthand, msg := range f.ShorthandsDeprecated {
+			clone.ShorthandsDeprecated[shorthand] = msg
+		}
+	}
+	if f.Annotations != nil {
+		clone.Annotations = make(map[string][]string, len(f.Annotations))
+		for key, values := range f.Annotations {
+			clone.Annotations[key] = append([]string(nil), values...)
+		}
+	}
+
+	return &clone, nil
+}
+
+// mapFlags returns the flags mapped to their copies.
+func mapFlags(flags []*Flag, copies map[*Flag]*Flag) []*Flag {
+	mapped := make([]*Flag, 0, len(flags))
+	for _, flag := range flags {
+		mapped = append(mapped, copies[flag])
+	}
+	return mapped
+}
+
+// mapNamedFlags returns a map with the same names as named, mapped to the
+// copies of the flags.
+func mapNamedFlags(named map[NormalizedName]*Flag, copies map[*Flag]*Flag) map[NormalizedName]*Flag {
+	mapped := make(map[NormalizedName]*Flag, len(named))
+	for name, flag := range named {
+		mapped[name] = copies[flag]
+	}
+	return mapped
+}
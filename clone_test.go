@@ -0,0 +1,144 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestClone(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetNormalizeFunc(func(f *zflag.FlagSet, name string) zflag.NormalizedName {
+		return zflag.NormalizedName(strings.ReplaceAll(name, "_", "-"))
+	})
+	name := f.String("user-name", "default", "usage", zflag.OptShorthand('n'), zflag.OptAlias("nick"),
+		zflag.OptAnnotation("key", []string{"value"}))
+	tags := f.StringSlice("tags", []string{"a"}, "usage")
+	f.Bool("json", false, "usage")
+	f.Bool("yaml", false, "usage")
+	f.MarkMutuallyExclusive("json", "yaml")
+
+	clone, err := f.Clone()
+	assertNoErr(t, err)
+	assertNoErr(t, clone.Parse([]string{"--user_name=clone", "--tags=b", "--json"}))
+	assertEqual(t, "clone", clone.Lookup("user-name").Value.String())
+	assertEqual(t, "default", *name)
+	assertDeepEqual(t, []string{"a"}, *tags)
+	assertEqual(t, true, clone.Changed("user-name"))
+	assertEqual(t, false, f.Changed("user-name"))
+	assertEqual(t, false, f.Parsed())
+
+	assertNoErr(t, clone.Set("nick", "alias"))
+	assertEqual(t, "alias", clone.Lookup("user-name").Value.String())
+	assertEqual(t, "default", *name)
+
+	clone.Lookup("user-name").Annotations["key"][0] = "changed"
+	assertDeepEqual(t, []string{"value"}, f.Lookup("user-name").Annotations["key"])
+
+	assertErrMsg(t, `flag(s) "--json", "--yaml" are mutually exclusive, but "--json", "--yaml" were set`,
+		clone.Parse([]string{"--yaml"}))
+	assertNoErr(t, f.Parse([]string{"--yaml"}))
+}
+
+func TestCloneKeepsValues(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	count := f.Int("count", 1, "usage")
+	assertNoErr(t, f.Set("count", "2"))
+
+	clone, err := f.Clone()
+	assertNoErr(t, err)
+	assertEqual(t, "2", clone.Lookup("count").Value.String())
+	assertEqual(t, false, clone.Changed("count"))
+	assertNoErr(t, clone.Set("count", "3"))
+	assertEqual(t, 2, *count)
+}
+
+func TestCloneAllTypes(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("bool", true, "usage")
+	f.BoolSlice("bool-slice", []bool{true}, "usage")
+	f.BytesHex("bytes-hex", []byte{1}, "usage")
+	f.BytesBase64("bytes-base64", []byte{1}, "usage")
+	f.Complex128("complex128", 1+2i, "usage")
+	f.Complex128Slice("complex128-slice", []complex128{1 + 2i}, "usage")
+	f.Count("count", "usage")
+	f.Duration("duration", time.Second, "usage")
+	f.DurationSlice("duration-slice", []time.Duration{time.Second}, "usage")
+	f.Enum("enum", "a", []string{"a", "b"}, "usage")
+	f.Float32("float32", 1.5, "usage")
+	f.Float32Slice("float32-slice", []float32{1.5}, "usage")
+	f.Float64("float64", 1.5, "usage")
+	f.Float64Slice("float64-slice", []float64{1.5}, "usage")
+	f.Int("int", 1, "usage")
+	f.IntSlice("int-slice", []int{1}, "usage")
+	f.Int8("int8", 1, "usage")
+	f.Int8Slice("int8-slice", []int8{1}, "usage")
+	f.Int16("int16", 1, "usage")
+	f.Int16Slice("int16-slice", []int16{1}, "usage")
+	f.Int32("int32", 1, "usage")
+	f.Int32Slice("int32-slice", []int32{1}, "usage")
+	f.Int64("int64", 1, "usage")
+	f.Int64Slice("int64-slice", []int64{1}, "usage")
+	f.IP("ip", net.IPv4(127, 0, 0, 1), "usage")
+	f.IPSlice("ip-slice", []net.IP{net.IPv4(127, 0, 0, 1)}, "usage")
+	f.IPMask("ip-mask", net.IPv4Mask(255, 255, 255, 0), "usage")
+	f.IPNet("ip-net", net.IPNet{IP: net.IPv4(10, 0, 0, 0), Mask: net.IPv4Mask(255, 0, 0, 0)}, "usage")
+	f.IPNetSlice("ip-net-slice", []net.IPNet{{IP: net.IPv4(10, 0, 0, 0), Mask: net.IPv4Mask(255, 0, 0, 0)}}, "usage")
+	f.String("string", "a", "usage")
+	f.StringSlice("string-slice", []string{"a"}, "usage")
+	f.StringToInt("string-to-int", map[string]int{"a": 1}, "usage")
+	f.StringToInt64("string-to-int64", map[string]int64{"a": 1}, "usage")
+	f.StringToString("string-to-string", map[string]string{"a": "b"}, "usage")
+	f.Time("time", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), []string{time.RFC3339}, "usage")
+	f.Uint("uint", 1, "usage")
+	f.UintSlice("uint-slice", []uint{1}, "usage")
+	f.Uint8("uint8", 1, "usage")
+	f.Uint8Slice("uint8-slice", []uint8{1}, "usage")
+	f.Uint16("uint16", 1, "usage")
+	f.Uint16Slice("uint16-slice", []uint16{1}, "usage")
+	f.Uint32("uint32", 1, "usage")
+	f.Uint32Slice("uint32-slice", []uint32{1}, "usage")
+	f.Uint64("uint64", 1, "usage")
+	f.Uint64Slice("uint64-slice", []uint64{1}, "usage")
+	args := []string{
+		"--bool=false", "--bool-slice=false", "--bytes-hex=02", "--bytes-base64=Ag==", "--complex128=3+4i",
+		"--complex128-slice=3+4i", "--count", "--duration=2s", "--duration-slice=2s", "--enum=b",
+		"--float32=2.5", "--float32-slice=2.5", "--float64=2.5", "--float64-slice=2.5", "--int=2", "--int-slice=2",
+		"--int8=2", "--int8-slice=2", "--int16=2", "--int16-slice=2", "--int32=2", "--int32-slice=2",
+		"--int64=2", "--int64-slice=2", "--ip=10.0.0.1", "--ip-slice=10.0.0.1", "--ip-mask=255.255.0.0",
+		"--ip-net=192.168.0.0/16", "--ip-net-slice=192.168.0.0/16", "--string=b", "--string-slice=b",
+		"--string-to-int=b=2", "--string-to-int64=b=2", "--string-to-string=b=c", "--time=2021-01-02T03:04:05Z",
+		"--uint=2", "--uint-slice=2", "--uint8=2", "--uint8-slice=2", "--uint16=2", "--uint16-slice=2",
+		"--uint32=2", "--uint32-slice=2", "--uint64=2", "--uint64-slice=2",
+	}
+
+	clone, err := f.Clone()
+	assertNoErr(t, err)
+	assertNoErr(t, clone.Parse(args))
+	assertEqual(t, 0, f.NFlag())
+	f.VisitAll(func(flag *zflag.Flag) {
+		assertEqualf(t, flag.DefValue, flag.Value.String(), "flag %q changed by the clone", flag.Name)
+	})
+
+	assertNoErr(t, f.Parse(args))
+	assertEqual(t, f.NFlag(), clone.NFlag())
+	f.VisitAll(func(flag *zflag.Flag) {
+		assertEqualf(t, flag.Value.String(), clone.Lookup(flag.Name).Value.String(), "flag %q", flag.Name)
+	})
+}
+
+func TestCloneNotCloner(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Var(new(customValue), "custom", "usage")
+
+	_, err := f.Clone()
+	assertErrMsg(t, `unable to clone flag "custom": value of type *zflag_test.customValue does not implement Cloner`, err)
+}
@@ -9,33 +9,50 @@ import (
 )
 
 // -- complex128 Value
-type complex128Value complex128
+type complex128Value struct {
+	value    *complex128
+	defValue complex128
+}
 
 var _ Value = (*complex128Value)(nil)
 var _ Getter = (*complex128Value)(nil)
 var _ Typed = (*complex128Value)(nil)
+var _ Cloner = (*complex128Value)(nil)
+var _ Resetter = (*complex128Value)(nil)
 
 func newComplex128Value(val complex128, p *complex128) *complex128Value {
 	*p = val
-	return (*complex128Value)(p)
+	return &complex128Value{value: p, defValue: val}
 }
 
 func (f *complex128Value) Get() interface{} {
-	return complex128(*f)
+	return *f.value
 }
 
 func (f *complex128Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseComplex(val, 128)
-	*f = complex128Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*f.value = v
+	return nil
 }
 
 func (f *complex128Value) Type() string {
 	return "complex128"
 }
 
-func (f *complex128Value) String() string { return strconv.FormatComplex(complex128(*f), 'g', -1, 128) }
+func (f *complex128Value) Clone() Value {
+	value := *f.value
+	return &complex128Value{value: &value, defValue: f.defValue}
+}
+
+func (f *complex128Value) Reset() {
+	*f.value = f.defValue
+}
+
+func (f *complex128Value) String() string { return strconv.FormatComplex(*f.value, 'g', -1, 128) }
 
 // GetComplex128 return the complex128 value of a flag with the given name
 func (fs *FlagSet) GetComplex128(name string) (complex128, error) {
@@ -14,19 +14,23 @@ import (
 
 // -- complex128Slice Value
 type complex128SliceValue struct {
-	value   *[]complex128
-	changed bool
+	value    *[]complex128
+	changed  bool
+	defaults []complex128
 }
 
 var _ Value = (*complex128SliceValue)(nil)
 var _ Getter = (*complex128SliceValue)(nil)
 var _ SliceValue = (*complex128SliceValue)(nil)
 var _ Typed = (*complex128SliceValue)(nil)
+var _ Cloner = (*complex128SliceValue)(nil)
+var _ Resetter = (*complex128SliceValue)(nil)
 
 func newComplex128SliceValue(val []complex128, p *[]complex128) *complex128SliceValue {
 	isv := new(complex128SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -54,6 +58,16 @@ func (s *complex128SliceValue) Type() string {
 	return "complex128Slice"
 }
 
+func (s *complex128SliceValue) Clone() Value {
+	value := append([]complex128(nil), *s.value...)
+	return &complex128SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *complex128SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *complex128SliceValue) String() string {
 	if s.value == nil || *s.value == nil {
 		return "[]"
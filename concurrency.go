@@ -0,0 +1,51 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"sync"
+)
+
+// SetConcurrencySafe enables or disables synchronization of the values of the
+// flags. When enabled, Set, LoadConfig and the values read from the providers
+// can be used concurrently with Get, the typed getters like GetInt, Lookup,
+// Changed, ValueString, Visit and VisitAll. The flags themselves are not
+// synchronized, so their values must be read through the FlagSet, also in the
+// functions passed to Visit and VisitAll, and not through Flag.Value or the
+// variables passed to e.g. IntVar. Validators and the functions passed to
+// Visit and VisitAll run without the FlagSet being locked, so they can use it.
+// Defining flags and parsing should be done before the FlagSet is shared, and
+// this must be called before that.
+func (fs *FlagSet) SetConcurrencySafe(safe bool) {
+	if !safe {
+		fs.mu = nil
+	} else if fs.mu == nil {
+		fs.mu = new(sync.RWMutex)
+	}
+}
+
+// SetConcurrencySafe enables or disables synchronization of the values of the command-line flags.
+func SetConcurrencySafe(safe bool) {
+	CommandLine.SetConcurrencySafe(safe)
+}
+
+// lock locks the FlagSet for writing if it is concurrency safe, and returns
+// the function to unlock it.
+func (fs *FlagSet) lock() func() {
+	if fs.mu == nil {
+		return func() {}
+	}
+	fs.mu.Lock()
+	return fs.mu.Unlock
+}
+
+// rlock locks the FlagSet for reading if it is concurrency safe, and returns
+// the function to unlock it.
+func (fs *FlagSet) rlock() func() {
+	if fs.mu == nil {
+		return func() {}
+	}
+	fs.mu.RLock()
+	return fs.mu.RUnlock
+}
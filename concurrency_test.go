@@ -0,0 +1,171 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+// TestConcurrencySafe should be run with -race.
+func TestConcurrencySafe(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetConcurrencySafe(true)
+	f.Int("count", 0, "usage")
+	f.StringSlice("tags", nil, "usage")
+	f.String("name", "", "usage")
+	assertNoErr(t, f.Parse([]string{"--name=a"}))
+
+	// the goroutines send their errors, as only the test goroutine may fail the test.
+	errs := make(chan error, 3*100+4*100*6)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			errs <- f.Set("count", strconv.Itoa(i))
+			errs <- f.Set("tags", strconv.Itoa(i))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			errs <- f.Set("name", strconv.Itoa(i))
+		}
+	}()
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				_, err := f.GetInt("count")
+				errs <- err
+				_, err = f.GetStringSlice("tags")
+				errs <- err
+				_, err = f.Get("name")
+				errs <- err
+				_ = f.Changed("count")
+				_ = f.Lookup("tags")
+				_ = f.NFlag()
+				f.Visit(func(flag *zflag.Flag) {
+					_ = f.Changed(flag.Name)
+				})
+				f.VisitAll(func(flag *zflag.Flag) {
+					_, err := f.ValueString(flag.Name)
+					errs <- err
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assertNoErr(t, err)
+	}
+
+	count, err := f.GetInt("count")
+	assertNoErr(t, err)
+	assertEqual(t, 99, count)
+	tags, err := f.GetStringSlice("tags")
+	assertNoErr(t, err)
+	assertEqual(t, 100, len(tags))
+	assertEqual(t, 3, f.NFlag())
+}
+
+func TestConcurrencySafeDisabled(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetConcurrencySafe(true)
+	f.SetConcurrencySafe(false)
+	f.Int("count", 0, "usage")
+	assertNoErr(t, f.Set("count", "1"))
+	assertEqual(t, true, f.Changed("count"))
+}
+
+func TestConcurrencySafeValidatorReadsFlags(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetConcurrencySafe(true)
+	f.Int("max", 10, "usage")
+	f.Int("count", 0, "usage", zflag.OptValidate(func(v interface{}) error {
+		if v.(int) > f.MustGetInt("max") {
+			return errors.New("must not be greater than --max")
+		}
+		return nil
+	}))
+	f.String("name", "", "usage", zflag.OptValidate(func(v interface{}) error {
+		if v.(string) == f.MustGetString("other") {
+			return errors.New("must differ from --other")
+		}
+		return nil
+	}))
+	f.String("other", "a", "usage")
+
+	var setErrs []error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		setErrs = append(setErrs,
+			f.Set("count", "5"),
+			f.Set("count", "11"),
+			f.Set("name", "a"),
+			f.Parse([]string{"--count=7", "--name=b"}))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("setting a flag whose validator reads other flags deadlocked")
+	}
+	assertNoErr(t, setErrs[0])
+	assertErrMsg(t, `invalid argument "11" for "--count" flag: must not be greater than --max`, setErrs[1])
+	assertErrMsg(t, `invalid argument "a" for "--name" flag: must differ from --other`, setErrs[2])
+	assertNoErr(t, setErrs[3])
+	assertEqual(t, 7, f.MustGetInt("count"))
+	assertEqual(t, "b", f.MustGetString("name"))
+}
+
+func TestConcurrencySafeVisitUsesFlagSet(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetConcurrencySafe(true)
+	f.Int("count", 0, "usage")
+	f.String("name", "", "usage")
+
+	setting := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		f.VisitAll(func(flag *zflag.Flag) {
+			if flag.Name != "count" {
+				return
+			}
+			// a Set is waiting for the lock while the FlagSet is used here.
+			close(setting)
+			time.Sleep(10 * time.Millisecond)
+			_ = f.Changed("name")
+			if _, err = f.ValueString("name"); err == nil {
+				err = f.Set("count", "1")
+			}
+		})
+		done <- err
+	}()
+
+	<-setting
+	assertNoErr(t, f.Set("name", "a"))
+	select {
+	case err := <-done:
+		assertNoErr(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("using the FlagSet in VisitAll deadlocked")
+	}
+	assertEqual(t, 1, f.MustGetInt("count"))
+	assertEqual(t, "a", f.MustGetString("name"))
+}
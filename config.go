@@ -0,0 +1,673 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ConfigFormat is the format of a configuration read by LoadConfig.
+type ConfigFormat int
+
+const (
+	// ConfigJSON is a JSON object. Nested objects are joined with a '.',
+	// arrays are used as list values.
+	ConfigJSON ConfigFormat = iota
+	// ConfigYAML is a subset of YAML supporting nested mappings, block and
+	// flow sequences of scalars, quoted scalars and comments.
+	ConfigYAML
+	// ConfigTOML is a subset of TOML supporting tables, dotted keys, strings,
+	// numbers, booleans, arrays of scalars, inline tables and comments.
+	ConfigTOML
+	// ConfigINI is an INI file. Keys in a section are prefixed with the section
+	// name and a '.', keys which are repeated are used as list values.
+	ConfigINI
+	// ConfigDotenv is a dotenv file. Keys are matched against the environment
+	// variable of a flag first (see OptEnv and SetEnvPrefix), and otherwise
+	// against the flag name, lower-cased and with '_' replaced by '-'.
+	ConfigDotenv
+)
+
+// String returns the name of the config format.
+func (c ConfigFormat) String() string {
+	switch c {
+	case ConfigJSON:
+		return "json"
+	case ConfigYAML:
+		return "yaml"
+	case ConfigTOML:
+		return "toml"
+	case ConfigINI:
+		return "ini"
+	case ConfigDotenv:
+		return "dotenv"
+	}
+	return "ConfigFormat(" + strconv.Itoa(int(c)) + ")"
+}
+
+// configEntry is a single key read from a config.
+type configEntry struct {
+	key    string
+	values []string
+	isList bool
+}
+
+// LoadConfig reads a config in the given format from r and sets the flags
+// whose normalized names match the keys. Values are set through the Value of
+// a flag, or replace the whole value of slice and map flags, so that a value
+// from the command line replaces them too. Keys of a map flag, such as
+// StringToString, can be set using a nested object or section named after the
+// flag.
+//
+// Flags whose value was read from a source with a higher precedence, see
+// SetSourcePrecedence, are left untouched, and values read from a config do
+// not mark a flag as changed. A list of values returns an error unless the
+// flag is a slice or map flag. Unknown keys return an error, unless
+// ParseErrorsAllowList.UnknownFlags is set, in which case they are added to
+// the unknown flags with dashes, see GetUnknownFlags.
+func (fs *FlagSet) LoadConfig(r io.Reader, format ConfigFormat) error {
+	entries, err := parseConfig(r, format)
+	if err != nil {
+		return fmt.Errorf("invalid %s config: %w", format, err)
+	}
+
+	for _, entry := range entries {
+		flag, mapKey := fs.lookupConfigKey(entry.key, format == ConfigDotenv)
+		if flag == nil {
+			if fs.ParseErrorsAllowList.UnknownFlags {
+				fs.addUnknownFlag(getFlagWithDashes(entry.key))
+				continue
+			}
+			return fmt.Errorf("config key %q: %w", entry.key, NewUnknownFlagError(entry.key))
+		}
+
+		if entry.isList && !acceptsList(flag, mapKey) {
+			return fmt.Errorf("config key %q: flag %s does not accept a list of values", entry.key, getFlagWithDashes(flag.Name))
+		}
+
+		if !fs.overrides(SourceConfig, flag) {
+			continue
+		}
+
+		if err := fs.setFromConfig(flag, mapKey, entry); err != nil {
+			return fmt.Errorf("config key %q: %w", entry.key, err)
+		}
+	}
+
+	return nil
+}
+
+// LoadConfig reads a config in the given format into the command-line flags.
+// See FlagSet.LoadConfig for more information.
+func LoadConfig(r io.Reader, format ConfigFormat) error {
+	return CommandLine.LoadConfig(r, format)
+}
+
+func parseConfig(r io.Reader, format ConfigFormat) ([]configEntry, error) {
+	switch format {
+	case ConfigJSON:
+		return parseJSONConfig(r)
+	case ConfigYAML:
+		return parseYAMLConfig(r)
+	case ConfigTOML:
+		return parseTOMLConfig(r)
+	case ConfigINI:
+		return parseINIConfig(r)
+	case ConfigDotenv:
+		return parseDotenvConfig(r)
+	}
+	return nil, fmt.Errorf("unsupported config format")
+}
+
+// lookupConfigKey returns the flag for a config key. If the key addresses an
+// entry of a map flag, such as "labels.app" for the flag "labels", the map
+// key is returned as well.
+func (fs *FlagSet) lookupConfigKey(key string, isEnv bool) (*Flag, string) {
+	if isEnv {
+		for _, flag := range fs.formal {
+			if flag.EnvVar == key {
+				return flag, ""
+			}
+		}
+		key = strings.ToLower(strings.ReplaceAll(key, "_", "-"))
+	}
+
+	if flag := fs.Lookup(key); flag != nil {
+		return flag, ""
+	}
+
+	for i := strings.LastIndexByte(key, '.'); i > 0; i = strings.LastIndexByte(key[:i], '.') {
+		if flag := fs.Lookup(key[:i]); flag != nil && isMapValue(flag.Value) {
+			return flag, key[i+1:]
+		}
+	}
+
+	return nil, ""
+}
+
+// acceptsList returns whether a list of values can be set on the flag, which
+// is the case for slice flags and for map flags when no map key is given.
+func acceptsList(flag *Flag, mapKey string) bool {
+	if _, ok := flag.Value.(SliceValue); ok {
+		return true
+	}
+	return mapKey == "" && isMapValue(flag.Value)
+}
+
+func isMapValue(v Value) bool {
+	_, ok := v.(mapValue)
+	return ok
+}
+
+func (fs *FlagSet) setFromConfig(flag *Flag, mapKey string, entry configEntry) error {
+	values := entry.values
+	if mapKey != "" {
+		values = make([]string, len(entry.values))
+		for i, value := range entry.values {
+			values[i] = mapKey + "=" + value
+		}
+	}
+	return fs.setFromSource(flag, values, SourceConfig)
+}
+
+func parseJSONConfig(r io.Reader) ([]configEntry, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected an object, got %v", tok)
+	}
+
+	var entries []configEntry
+	if err := walkJSONObject(dec, "", &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func walkJSONObject(dec *json.Decoder, prefix string, entries *[]configEntry) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if err := walkJSONValue(dec, prefix+tok.(string), entries); err != nil {
+			return err
+		}
+	}
+
+	// consume the closing '}'
+	_, err := dec.Token()
+	return err
+}
+
+func walkJSONValue(dec *json.Decoder, key string, entries *[]configEntry) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		return walkJSONObject(dec, key+".", entries)
+	case json.Delim('['):
+		entry := configEntry{key: key, values: []string{}, isList: true}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			v, ok := jsonScalar(tok)
+			if !ok {
+				return fmt.Errorf("key %q: arrays may only contain scalar values", key)
+			}
+			entry.values = append(entry.values, v)
+		}
+		// consume the closing ']'
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+		*entries = append(*entries, entry)
+	case nil:
+		// null leaves the flag untouched
+	default:
+		v, _ := jsonScalar(tok)
+		*entries = append(*entries, configEntry{key: key, values: []string{v}})
+	}
+
+	return nil
+}
+
+func jsonScalar(tok json.Token) (string, bool) {
+	switch v := tok.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// yamlParent is a mapping key whose nested keys are being read.
+type yamlParent struct {
+	indent int
+	prefix string
+}
+
+//nolint:funlen
+func parseYAMLConfig(r io.Reader) ([]configEntry, error) {
+	var (
+		entries    []configEntry
+		parents    = []yamlParent{{indent: -1}}
+		pending    *yamlParent // key without a value, which may be followed by nested keys or a list
+		list       = -1        // index in entries of the list being read
+		listIndent int
+	)
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimRight(stripComment(scanner.Text(), '#'), " \t")
+		content := strings.TrimLeft(line, " ")
+		indent := len(line) - len(content)
+		if content == "" || content == "---" || content == "..." {
+			continue
+		}
+		if content[0] == '\t' {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", lineNo)
+		}
+
+		if content == "-" || strings.HasPrefix(content, "- ") {
+			switch {
+			case pending != nil && indent >= pending.indent:
+				entries = append(entries, configEntry{key: pending.prefix, values: []string{}, isList: true})
+				list, listIndent, pending = len(entries)-1, indent, nil
+			case list < 0 || indent != listIndent:
+				return nil, fmt.Errorf("line %d: unexpected list item", lineNo)
+			}
+
+			v, err := unquoteConfigValue(strings.TrimSpace(content[1:]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			entries[list].values = append(entries[list].values, v)
+			continue
+		}
+		list = -1
+
+		i := yamlKeyEnd(content)
+		if i < 0 {
+			return nil, fmt.Errorf("line %d: expected a key followed by ':'", lineNo)
+		}
+		key, err := unquoteConfigValue(content[:i])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		value := strings.TrimSpace(content[i+1:])
+
+		if pending != nil {
+			if indent > pending.indent {
+				parents = append(parents, yamlParent{indent: pending.indent, prefix: pending.prefix + "."})
+			}
+			pending = nil
+		}
+		for len(parents) > 1 && indent <= parents[len(parents)-1].indent {
+			parents = parents[:len(parents)-1]
+		}
+		key = parents[len(parents)-1].prefix + key
+
+		switch {
+		case value == "":
+			pending = &yamlParent{indent: indent, prefix: key}
+		case value == "~" || value == "null":
+			// null leaves the flag untouched
+		case value[0] == '|' || value[0] == '>' || value[0] == '{' || value[0] == '&' || value[0] == '*':
+			return nil, fmt.Errorf("line %d: unsupported value %q", lineNo, value)
+		case value[0] == '[':
+			values, err := parseFlowList(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			entries = append(entries, configEntry{key: key, values: values, isList: true})
+		default:
+			v, err := unquoteConfigValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			entries = append(entries, configEntry{key: key, values: []string{v}})
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+// yamlKeyEnd returns the index of the ':' separating the key from the value,
+// or -1 if there is none.
+func yamlKeyEnd(s string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ':' && (i == len(s)-1 || s[i+1] == ' '):
+			return i
+		}
+	}
+	return -1
+}
+
+//nolint:funlen
+func parseTOMLConfig(r io.Reader) ([]configEntry, error) {
+	var (
+		entries []configEntry
+		prefix  string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(stripComment(scanner.Text(), '#'))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") {
+			return nil, fmt.Errorf("line %d: arrays of tables are not supported", lineNo)
+		}
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("line %d: invalid table header", lineNo)
+			}
+			table, err := parseTOMLKey(line[1 : len(line)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			prefix = table + "."
+			continue
+		}
+
+		i := strings.IndexByte(line, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("line %d: expected a key followed by '='", lineNo)
+		}
+		key, err := parseTOMLKey(line[:i])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		key = prefix + key
+		value := strings.TrimSpace(line[i+1:])
+
+		// arrays may span multiple lines
+		for strings.HasPrefix(value, "[") && !flowListComplete(value) && scanner.Scan() {
+			lineNo++
+			value += " " + strings.TrimSpace(stripComment(scanner.Text(), '#'))
+		}
+
+		switch {
+		case value == "":
+			return nil, fmt.Errorf("line %d: missing value for key %q", lineNo, key)
+		case strings.HasPrefix(value, `"""`) || strings.HasPrefix(value, `'''`):
+			return nil, fmt.Errorf("line %d: multi-line strings are not supported", lineNo)
+		case value[0] == '[':
+			values, err := parseFlowList(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			entries = append(entries, configEntry{key: key, values: values, isList: true})
+		case value[0] == '{':
+			inline, err := parseTOMLInlineTable(key, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			entries = append(entries, inline...)
+		default:
+			v, err := unquoteConfigValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			entries = append(entries, configEntry{key: key, values: []string{v}})
+		}
+	}
+
+	return entries, scanner.Err()
+}
+
+// parseTOMLKey parses a bare, quoted or dotted key.
+func parseTOMLKey(s string) (string, error) {
+	parts, err := splitQuoted(strings.TrimSpace(s), '.')
+	if err != nil {
+		return "", err
+	}
+	for i, part := range parts {
+		if parts[i], err = unquoteConfigValue(part); err != nil {
+			return "", err
+		}
+		if parts[i] == "" {
+			return "", fmt.Errorf("invalid key %q", s)
+		}
+	}
+	return strings.Join(parts, "."), nil
+}
+
+func parseTOMLInlineTable(key, s string) ([]configEntry, error) {
+	if s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("unterminated inline table")
+	}
+
+	pairs, err := splitQuoted(s[1:len(s)-1], ',')
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []configEntry
+	for _, pair := range pairs {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		i := strings.IndexByte(pair, '=')
+		if i < 0 {
+			return nil, fmt.Errorf("expected a key followed by '=' in inline table")
+		}
+		k, err := parseTOMLKey(pair[:i])
+		if err != nil {
+			return nil, err
+		}
+		v, err := unquoteConfigValue(strings.TrimSpace(pair[i+1:]))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, configEntry{key: key + "." + k, values: []string{v}})
+	}
+	return entries, nil
+}
+
+func parseINIConfig(r io.Reader) ([]configEntry, error) {
+	var (
+		entries []configEntry
+		seen    = map[string]int{}
+		prefix  string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("line %d: invalid section header", lineNo)
+			}
+			prefix = strings.TrimSpace(line[1:len(line)-1]) + "."
+			continue
+		}
+
+		i := strings.IndexAny(line, "=:")
+		if i <= 0 {
+			return nil, fmt.Errorf("line %d: expected a key followed by '=' or ':'", lineNo)
+		}
+		key := prefix + strings.TrimSpace(line[:i])
+		value, err := unquoteConfigValue(strings.TrimSpace(line[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		if idx, ok := seen[key]; ok {
+			entries[idx].values = append(entries[idx].values, value)
+			entries[idx].isList = true
+			continue
+		}
+		seen[key] = len(entries)
+		entries = append(entries, configEntry{key: key, values: []string{value}})
+	}
+
+	return entries, scanner.Err()
+}
+
+func parseDotenvConfig(r io.Reader) ([]configEntry, error) {
+	var entries []configEntry
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		i := strings.IndexByte(line, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("line %d: expected a key followed by '='", lineNo)
+		}
+		key := strings.TrimSpace(line[:i])
+		value, err := unquoteConfigValue(strings.TrimSpace(stripComment(line[i+1:], '#')))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		entries = append(entries, configEntry{key: key, values: []string{value}})
+	}
+
+	return entries, scanner.Err()
+}
+
+// stripComment removes a comment started by marker, which is either at the
+// start of s or preceded by whitespace, and not within quotes.
+func stripComment(s string, marker byte) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == marker && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// unquoteConfigValue removes the quotes from a double or single quoted value.
+// Escape sequences are only interpreted in double quoted values.
+func unquoteConfigValue(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 || (s[0] != '"' && s[0] != '\'') {
+		return s, nil
+	}
+
+	if len(s) < 2 || s[len(s)-1] != s[0] {
+		return "", fmt.Errorf("unterminated quoted value %s", s)
+	}
+	if s[0] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+
+	v, err := strconv.Unquote(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid quoted value %s", s)
+	}
+	return v, nil
+}
+
+// splitQuoted splits s on sep, ignoring any sep within quotes.
+func splitQuoted(s string, sep byte) ([]string, error) {
+	var (
+		parts []string
+		quote byte
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quoted value in %s", s)
+	}
+	return append(parts, s[start:]), nil
+}
+
+// flowListComplete reports whether the closing ']' of a list is found.
+func flowListComplete(s string) bool {
+	parts, err := splitQuoted(s, ']')
+	return err == nil && len(parts) > 1
+}
+
+// parseFlowList parses a list of scalars in the form [a, "b", 'c'].
+func parseFlowList(s string) ([]string, error) {
+	if s[len(s)-1] != ']' {
+		return nil, fmt.Errorf("unterminated list %s", s)
+	}
+
+	items, err := splitQuoted(s[1:len(s)-1], ',')
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(items))
+	for i, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" && i == len(items)-1 {
+			// trailing comma
+			continue
+		}
+		if item != "" && (item[0] == '[' || item[0] == '{') {
+			return nil, fmt.Errorf("lists may only contain scalar values")
+		}
+		v, err := unquoteConfigValue(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
@@ -0,0 +1,370 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+type configFlags struct {
+	name     string
+	port     int
+	debug    bool
+	logLevel string
+	tags     []string
+	ids      []int
+	labels   map[string]string
+}
+
+func TestLoadConfig(t *testing.T) {
+	expected := configFlags{
+		name:     "my app",
+		port:     8080,
+		debug:    true,
+		logLevel: "debug",
+		tags:     []string{"a", "b c"},
+		ids:      []int{1, 2},
+		labels:   map[string]string{"app": "web", "tier": "front"},
+	}
+
+	tests := []struct {
+		name   string
+		format zflag.ConfigFormat
+		config string
+	}{
+		{
+			name:   "json",
+			format: zflag.ConfigJSON,
+			config: `{
+				"name": "my app",
+				"port": 8080,
+				"debug": true,
+				"log": {"level": "debug"},
+				"tags": ["a", "b c"],
+				"ids": [1, 2],
+				"labels": {"app": "web", "tier": "front"}
+			}`,
+		},
+		{
+			name:   "yaml",
+			format: zflag.ConfigYAML,
+			config: strings.Join([]string{
+				"---",
+				"# comment",
+				`name: "my app" # trailing comment`,
+				"port: 8080",
+				"debug: true",
+				"log:",
+				"  level: debug",
+				"tags:",
+				"  - a",
+				"  - 'b c'",
+				"ids: [1, 2]",
+				"labels:",
+				"  app: web",
+				"  tier: front",
+			}, "\n"),
+		},
+		{
+			name:   "toml",
+			format: zflag.ConfigTOML,
+			config: strings.Join([]string{
+				"# comment",
+				`name = "my app" # trailing comment`,
+				"port = 8080",
+				"debug = true",
+				"log.level = 'debug'",
+				"tags = [",
+				`  "a",`,
+				`  "b c",`,
+				"]",
+				"ids = [1, 2]",
+				`labels = { app = "web" }`,
+				"",
+				"[labels]",
+				`tier = "front"`,
+			}, "\n"),
+		},
+		{
+			name:   "ini",
+			format: zflag.ConfigINI,
+			config: strings.Join([]string{
+				"; comment",
+				"name = my app",
+				"port = 8080",
+				"debug = true",
+				"tags = a",
+				`tags = "b c"`,
+				"ids = 1",
+				"ids = 2",
+				"[log]",
+				"level = debug",
+				"[labels]",
+				"app = web",
+				"tier: front",
+			}, "\n"),
+		},
+		{
+			name:   "dotenv",
+			format: zflag.ConfigDotenv,
+			config: strings.Join([]string{
+				"# comment",
+				`NAME="my app" # trailing comment`,
+				"export PORT=8080",
+				"DEBUG=true",
+				"LOG.LEVEL=debug",
+				"TAGS=a",
+				"IDS=1",
+				"LABELS=app=web",
+			}, "\n"),
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var flags configFlags
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.StringVar(&flags.name, "name", "default", "usage")
+			f.IntVar(&flags.port, "port", 80, "usage")
+			f.BoolVar(&flags.debug, "debug", false, "usage")
+			f.StringVar(&flags.logLevel, "log.level", "info", "usage")
+			f.StringSliceVar(&flags.tags, "tags", []string{"default"}, "usage")
+			f.IntSliceVar(&flags.ids, "ids", []int{}, "usage")
+			f.StringToStringVar(&flags.labels, "labels", map[string]string{"default": "true"}, "usage")
+			err := f.LoadConfig(strings.NewReader(test.config), test.format)
+			assertNoErr(t, err)
+
+			if test.format == zflag.ConfigDotenv {
+				// dotenv files cannot hold lists or maps
+				assertDeepEqual(t, []string{"a"}, flags.tags)
+				assertDeepEqual(t, []int{1}, flags.ids)
+				assertDeepEqual(t, map[string]string{"app": "web"}, flags.labels)
+				flags.tags, flags.ids, flags.labels = expected.tags, expected.ids, expected.labels
+			}
+			assertDeepEqual(t, expected, flags)
+
+			f.VisitAll(func(flag *zflag.Flag) {
+				if flag.Changed {
+					t.Errorf("flag %q was marked as changed", flag.Name)
+				}
+			})
+		})
+	}
+}
+
+func TestLoadConfigPrecedence(t *testing.T) {
+	os.Setenv("ZFLAG_TEST_CONFIG_NAME", "from-env")
+	defer os.Unsetenv("ZFLAG_TEST_CONFIG_NAME")
+
+	config := `{"name": "from-config", "port": 1, "debug": true, "tags": ["from-config"]}`
+
+	// loaded before parsing
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	name := f.String("name", "default", "usage", zflag.OptEnv("ZFLAG_TEST_CONFIG_NAME"))
+	port := f.Int("port", 80, "usage")
+	debug := f.Bool("debug", false, "usage")
+	tags := f.StringSlice("tags", []string{"default"}, "usage")
+	assertNoErr(t, f.LoadConfig(strings.NewReader(config), zflag.ConfigJSON))
+	assertNoErr(t, f.Parse([]string{"--port=2", "--tags=from-cli"}))
+	assertEqual(t, "from-env", *name)
+	assertEqual(t, 2, *port)
+	assertEqual(t, true, *debug)
+	assertDeepEqual(t, []string{"from-cli"}, *tags)
+
+	// loaded after parsing
+	f = zflag.NewFlagSet("test", zflag.ContinueOnError)
+	name = f.String("name", "default", "usage", zflag.OptEnv("ZFLAG_TEST_CONFIG_NAME"))
+	port = f.Int("port", 80, "usage")
+	debug = f.Bool("debug", false, "usage")
+	tags = f.StringSlice("tags", []string{"default"}, "usage")
+	assertNoErr(t, f.Parse([]string{"--port=2", "--tags=from-cli"}))
+	assertNoErr(t, f.LoadConfig(strings.NewReader(config), zflag.ConfigJSON))
+	assertEqual(t, "from-env", *name)
+	assertEqual(t, 2, *port)
+	assertEqual(t, true, *debug)
+	assertDeepEqual(t, []string{"from-cli"}, *tags)
+}
+
+func TestLoadConfigMapPrecedence(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	labels := f.StringToString("labels", map[string]string{"default": "true"}, "usage")
+	counts := f.StringToInt("counts", nil, "usage")
+	limits := f.StringToInt64("limits", nil, "usage")
+	config := `{"labels": {"a": "1", "c": "3"}, "counts": {"a": 1, "b": 2}, "limits": {"a": 1}}`
+	assertNoErr(t, f.LoadConfig(strings.NewReader(config), zflag.ConfigJSON))
+	assertDeepEqual(t, map[string]string{"a": "1", "c": "3"}, *labels)
+
+	assertNoErr(t, f.Parse([]string{"--labels", "b=2", "--limits=b=2"}))
+	assertDeepEqual(t, map[string]string{"b": "2"}, *labels)
+	assertDeepEqual(t, map[string]int{"a": 1, "b": 2}, *counts)
+	assertDeepEqual(t, map[string]int64{"b": 2}, *limits)
+	assertEqual(t, false, f.Changed("counts"))
+}
+
+func TestLoadConfigUnknownKeys(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.ParseErrorsAllowList.UnknownFlags = true
+	name := f.String("name", "", "usage")
+	assertNoErr(t, f.LoadConfig(strings.NewReader(`{"unknown": 1, "name": "a", "nested": {"key": [1]}}`), zflag.ConfigJSON))
+	assertEqual(t, "a", *name)
+	assertDeepEqual(t, []string{"--unknown", "--nested.key"}, f.GetUnknownFlags())
+}
+
+func TestLoadConfigRequired(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("name", "", "usage", zflag.OptRequired())
+	assertNoErr(t, f.LoadConfig(strings.NewReader("name: test"), zflag.ConfigYAML))
+	assertNoErr(t, f.Parse([]string{}))
+}
+
+func TestLoadConfigNormalized(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetNormalizeFunc(wordSepNormalizeFunc)
+	logLevel := f.String("log-level", "", "usage")
+	assertNoErr(t, f.LoadConfig(strings.NewReader("log_level = debug"), zflag.ConfigINI))
+	assertEqual(t, "debug", *logLevel)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      zflag.ConfigFormat
+		config      string
+		allowList   bool
+		expectedErr string
+	}{
+		{
+			name:        "unknown key",
+			format:      zflag.ConfigJSON,
+			config:      `{"unknown": 1}`,
+			expectedErr: `config key "unknown": unknown flag: --unknown`,
+		},
+		{
+			name:      "unknown key allowed",
+			format:    zflag.ConfigJSON,
+			config:    `{"unknown": 1, "nested": {"unknown": [1]}}`,
+			allowList: true,
+		},
+		{
+			name:        "invalid value",
+			format:      zflag.ConfigYAML,
+			config:      "port: abc",
+			expectedErr: `config key "port": invalid argument "abc" for "--port" flag: strconv.ParseInt: parsing "abc": invalid syntax`,
+		},
+		{
+			name:        "invalid slice value",
+			format:      zflag.ConfigTOML,
+			config:      `ids = [1, "a"]`,
+			expectedErr: `config key "ids": invalid argument "1,a" for "--ids" flag: strconv.Atoi: parsing "a": invalid syntax`,
+		},
+		{
+			name:        "json array for scalar flag",
+			format:      zflag.ConfigJSON,
+			config:      `{"port": [2, 3]}`,
+			expectedErr: `config key "port": flag --port does not accept a list of values`,
+		},
+		{
+			name:        "yaml list for scalar flag",
+			format:      zflag.ConfigYAML,
+			config:      "name:\n  - a\n  - b",
+			expectedErr: `config key "name": flag --name does not accept a list of values`,
+		},
+		{
+			name:        "repeated ini key for scalar flag",
+			format:      zflag.ConfigINI,
+			config:      "port = 2\nport = 3",
+			expectedErr: `config key "port": flag --port does not accept a list of values`,
+		},
+		{
+			name:        "json array for map key",
+			format:      zflag.ConfigJSON,
+			config:      `{"labels": {"app": ["a", "b"]}}`,
+			expectedErr: `config key "labels.app": flag --labels does not accept a list of values`,
+		},
+		{
+			name:        "invalid json",
+			format:      zflag.ConfigJSON,
+			config:      `["a"]`,
+			expectedErr: `invalid json config: expected an object, got [`,
+		},
+		{
+			name:        "nested json array",
+			format:      zflag.ConfigJSON,
+			config:      `{"tags": [["a"]]}`,
+			expectedErr: `invalid json config: key "tags": arrays may only contain scalar values`,
+		},
+		{
+			name:        "yaml without colon",
+			format:      zflag.ConfigYAML,
+			config:      "name",
+			expectedErr: `invalid yaml config: line 1: expected a key followed by ':'`,
+		},
+		{
+			name:        "yaml unexpected list item",
+			format:      zflag.ConfigYAML,
+			config:      "name: a\n- b",
+			expectedErr: `invalid yaml config: line 2: unexpected list item`,
+		},
+		{
+			name:        "yaml block scalar",
+			format:      zflag.ConfigYAML,
+			config:      "name: |\n  a",
+			expectedErr: `invalid yaml config: line 1: unsupported value "|"`,
+		},
+		{
+			name:        "toml unterminated string",
+			format:      zflag.ConfigTOML,
+			config:      `name = "abc`,
+			expectedErr: `invalid toml config: line 1: unterminated quoted value "abc`,
+		},
+		{
+			name:        "toml array of tables",
+			format:      zflag.ConfigTOML,
+			config:      "[[labels]]",
+			expectedErr: `invalid toml config: line 1: arrays of tables are not supported`,
+		},
+		{
+			name:        "ini without separator",
+			format:      zflag.ConfigINI,
+			config:      "[section]\nname",
+			expectedErr: `invalid ini config: line 2: expected a key followed by '=' or ':'`,
+		},
+		{
+			name:        "dotenv without separator",
+			format:      zflag.ConfigDotenv,
+			config:      "NAME",
+			expectedErr: `invalid dotenv config: line 1: expected a key followed by '='`,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.ParseErrorsAllowList.UnknownFlags = test.allowList
+			f.String("name", "default", "usage")
+			f.Int("port", 80, "usage")
+			f.StringSlice("tags", []string{"default"}, "usage")
+			f.IntSlice("ids", []int{}, "usage")
+			f.StringToString("labels", map[string]string{"default": "true"}, "usage")
+			err := f.LoadConfig(strings.NewReader(test.config), test.format)
+			if test.expectedErr == "" {
+				assertNoErr(t, err)
+				return
+			}
+			assertErrMsg(t, test.expectedErr, err)
+		})
+	}
+}
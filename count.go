@@ -8,39 +8,56 @@ import (
 )
 
 // -- count Value
-type countValue int
+type countValue struct {
+	value    *int
+	defValue int
+}
 
 var _ Value = (*countValue)(nil)
 var _ Getter = (*countValue)(nil)
 var _ Typed = (*countValue)(nil)
 var _ OptionalValue = (*countValue)(nil)
+var _ Cloner = (*countValue)(nil)
+var _ Resetter = (*countValue)(nil)
 
 func newCountValue(val int, p *int) *countValue {
 	*p = val
-	return (*countValue)(p)
+	return &countValue{value: p, defValue: val}
 }
 
 func (i *countValue) Set(val string) error {
 	if val == "" {
-		*i++
+		*i.value++
 		return nil
 	}
 
 	v, err := strconv.ParseInt(val, 0, 0)
-	*i = countValue(v)
+	if err != nil {
+		return err
+	}
+	*i.value = int(v)
 
-	return err
+	return nil
 }
 
 func (i *countValue) Get() interface{} {
-	return int(*i)
+	return *i.value
 }
 
 func (i *countValue) Type() string {
 	return "count"
 }
 
-func (i *countValue) String() string { return strconv.Itoa(int(*i)) }
+func (i *countValue) Clone() Value {
+	value := *i.value
+	return &countValue{value: &value, defValue: i.defValue}
+}
+
+func (i *countValue) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *countValue) String() string { return strconv.Itoa(*i.value) }
 
 func (i *countValue) IsOptional() bool { return true }
 
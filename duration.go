@@ -9,33 +9,50 @@ import (
 )
 
 // -- time.Duration Value
-type durationValue time.Duration
+type durationValue struct {
+	value    *time.Duration
+	defValue time.Duration
+}
 
 var _ Value = (*durationValue)(nil)
 var _ Getter = (*durationValue)(nil)
 var _ Typed = (*durationValue)(nil)
+var _ Cloner = (*durationValue)(nil)
+var _ Resetter = (*durationValue)(nil)
 
 func newDurationValue(val time.Duration, p *time.Duration) *durationValue {
 	*p = val
-	return (*durationValue)(p)
+	return &durationValue{value: p, defValue: val}
 }
 
 func (d *durationValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := time.ParseDuration(val)
-	*d = durationValue(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*d.value = v
+	return nil
 }
 
 func (d *durationValue) Get() interface{} {
-	return time.Duration(*d)
+	return *d.value
 }
 
 func (d *durationValue) Type() string {
 	return "duration"
 }
 
-func (d *durationValue) String() string { return (*time.Duration)(d).String() }
+func (d *durationValue) Clone() Value {
+	value := *d.value
+	return &durationValue{value: &value, defValue: d.defValue}
+}
+
+func (d *durationValue) Reset() {
+	*d.value = d.defValue
+}
+
+func (d *durationValue) String() string { return d.value.String() }
 
 // GetDuration return the duration value of a flag with the given name
 func (fs *FlagSet) GetDuration(name string) (time.Duration, error) {
@@ -11,19 +11,23 @@ import (
 
 // -- durationSlice Value
 type durationSliceValue struct {
-	value   *[]time.Duration
-	changed bool
+	value    *[]time.Duration
+	changed  bool
+	defaults []time.Duration
 }
 
 var _ Value = (*durationSliceValue)(nil)
 var _ Getter = (*durationSliceValue)(nil)
 var _ SliceValue = (*durationSliceValue)(nil)
 var _ Typed = (*durationSliceValue)(nil)
+var _ Cloner = (*durationSliceValue)(nil)
+var _ Resetter = (*durationSliceValue)(nil)
 
 func newDurationSliceValue(val []time.Duration, p *[]time.Duration) *durationSliceValue {
 	dsv := new(durationSliceValue)
 	dsv.value = p
 	*dsv.value = val
+	dsv.defaults = val
 	return dsv
 }
 
@@ -51,6 +55,16 @@ func (s *durationSliceValue) Type() string {
 	return "durationSlice"
 }
 
+func (s *durationSliceValue) Clone() Value {
+	value := append([]time.Duration(nil), *s.value...)
+	return &durationSliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *durationSliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *durationSliceValue) String() string {
 	if s.value == nil || *s.value == nil {
 		return "[]"
@@ -0,0 +1,164 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// -- enum Value
+type enumValue struct {
+	value        *string
+	choices      []string
+	ignoreCase   bool
+	descriptions map[string]string
+	defValue     string
+}
+
+var _ Value = (*enumValue)(nil)
+var _ Getter = (*enumValue)(nil)
+var _ Typed = (*enumValue)(nil)
+var _ ChoiceValue = (*enumValue)(nil)
+var _ Cloner = (*enumValue)(nil)
+var _ Resetter = (*enumValue)(nil)
+
+func newEnumValue(val string, choices []string, p *string) *enumValue {
+	if len(choices) == 0 {
+		panic("enum flags need at least one choice")
+	}
+
+	e := &enumValue{value: p, choices: choices, defValue: val}
+	if val != "" && !e.isChoice(val) {
+		panic(fmt.Sprintf("default value %q of enum flag is not one of: %s", val, strings.Join(choices, ", ")))
+	}
+	*p = val
+	return e
+}
+
+func (e *enumValue) isChoice(val string) bool {
+	for _, choice := range e.choices {
+		if choice == val {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *enumValue) Set(val string) error {
+	for _, choice := range e.choices {
+		if choice == val || (e.ignoreCase && strings.EqualFold(choice, val)) {
+			*e.value = choice
+			return nil
+		}
+	}
+
+	return fmt.Errorf("must be one of: %s", strings.Join(e.choices, ", "))
+}
+
+func (e *enumValue) Get() interface{} {
+	return *e.value
+}
+
+func (e *enumValue) Type() string {
+	return "enum"
+}
+
+func (e *enumValue) Clone() Value {
+	clone := *e
+	value := *e.value
+	clone.value = &value
+	return &clone
+}
+
+func (e *enumValue) Reset() {
+	*e.value = e.defValue
+}
+
+func (e *enumValue) String() string { return *e.value }
+
+func (e *enumValue) Choices() []string {
+	return e.choices
+}
+
+func (e *enumValue) ChoiceDescription(choice string) string {
+	return e.descriptions[choice]
+}
+
+// OptEnumIgnoreCase makes an enum flag match its choices case-insensitively.
+// The value of the flag is always set to the choice as it was defined.
+func OptEnumIgnoreCase() Opt {
+	return func(f *Flag) error {
+		e, ok := f.Value.(*enumValue)
+		if !ok {
+			return fmt.Errorf("flag %q is not an enum flag", f.Name)
+		}
+
+		e.ignoreCase = true
+		return nil
+	}
+}
+
+// OptEnumDescriptions sets descriptions of the choices of an enum flag, which
+// are listed in the help message.
+func OptEnumDescriptions(descriptions map[string]string) Opt {
+	return func(f *Flag) error {
+		e, ok := f.Value.(*enumValue)
+		if !ok {
+			return fmt.Errorf("flag %q is not an enum flag", f.Name)
+		}
+
+		for choice := range descriptions {
+			if !e.isChoice(choice) {
+				return fmt.Errorf("description for %q of flag %q is not one of its choices", choice, f.Name)
+			}
+		}
+		e.descriptions = descriptions
+		return nil
+	}
+}
+
+// GetEnum return the enum value of a flag with the given name
+func (fs *FlagSet) GetEnum(name string) (string, error) {
+	val, err := fs.getFlagValue(name, "enum")
+	if err != nil {
+		return "", err
+	}
+	return val.(string), nil
+}
+
+// MustGetEnum is like GetEnum, but panics on error.
+func (fs *FlagSet) MustGetEnum(name string) string {
+	val, err := fs.GetEnum(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// EnumVar defines an enum flag with specified name, default value, allowed choices, and usage string.
+// The argument p points to a string variable in which to store the value of the flag.
+func (fs *FlagSet) EnumVar(p *string, name string, value string, choices []string, usage string, opts ...Opt) {
+	fs.Var(newEnumValue(value, choices, p), name, usage, opts...)
+}
+
+// EnumVar defines an enum flag with specified name, default value, allowed choices, and usage string.
+// The argument p points to a string variable in which to store the value of the flag.
+func EnumVar(p *string, name string, value string, choices []string, usage string, opts ...Opt) {
+	CommandLine.EnumVar(p, name, value, choices, usage, opts...)
+}
+
+// Enum defines an enum flag with specified name, default value, allowed choices, and usage string.
+// The return value is the address of a string variable that stores the value of the flag.
+func (fs *FlagSet) Enum(name string, value string, choices []string, usage string, opts ...Opt) *string {
+	var p string
+	fs.EnumVar(&p, name, value, choices, usage, opts...)
+	return &p
+}
+
+// Enum defines an enum flag with specified name, default value, allowed choices, and usage string.
+// The return value is the address of a string variable that stores the value of the flag.
+func Enum(name string, value string, choices []string, usage string, opts ...Opt) *string {
+	return CommandLine.Enum(name, value, choices, usage, opts...)
+}
@@ -0,0 +1,154 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestEnum(t *testing.T) {
+	choices := []string{"debug", "info", "warn"}
+	tests := []struct {
+		name          string
+		flagDefault   string
+		input         []string
+		expectedErr   string
+		expectedValue string
+		extraOpts     []zflag.Opt
+	}{
+		{
+			name:          "no value passed",
+			input:         []string{},
+			flagDefault:   "info",
+			expectedValue: "info",
+		},
+		{
+			name:          "no default",
+			input:         []string{},
+			expectedValue: "",
+		},
+		{
+			name:          "valid value",
+			input:         repeatFlag("--level", "warn"),
+			flagDefault:   "info",
+			expectedValue: "warn",
+		},
+		{
+			name:          "repeated value",
+			input:         repeatFlag("--level", "warn", "debug"),
+			expectedValue: "debug",
+		},
+		{
+			name:        "invalid value",
+			input:       repeatFlag("--level", "trace"),
+			expectedErr: `invalid argument "trace" for "--level" flag: must be one of: debug, info, warn`,
+		},
+		{
+			name:        "case sensitive",
+			input:       repeatFlag("--level", "WARN"),
+			expectedErr: `invalid argument "WARN" for "--level" flag: must be one of: debug, info, warn`,
+		},
+		{
+			name:          "ignore case",
+			input:         repeatFlag("--level", "WARN"),
+			expectedValue: "warn",
+			extraOpts:     []zflag.Opt{zflag.OptEnumIgnoreCase()},
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var level string
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.EnumVar(&level, "level", test.flagDefault, choices, "usage", test.extraOpts...)
+			err := f.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedValue, level)
+
+			getEnum, err := f.GetEnum("level")
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedValue, getEnum)
+
+			getEnumGet, err := f.Get("level")
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedValue, getEnumGet)
+
+			defer assertNoPanic(t)()
+			mustEnum := f.MustGetEnum("level")
+			assertEqual(t, test.expectedValue, mustEnum)
+		})
+	}
+}
+
+func TestEnumErrors(t *testing.T) {
+	t.Parallel()
+
+	var s string
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StringVar(&s, "s", "", "usage")
+	f.Enum("level", "info", []string{"info"}, "usage")
+	err := f.Parse([]string{})
+	assertNoErr(t, err)
+
+	_, err = f.GetEnum("s")
+	assertErr(t, err)
+
+	func() {
+		defer assertPanic(t)()
+		f.Enum("invalid-default", "trace", []string{"info"}, "usage")
+	}()
+
+	func() {
+		defer assertPanic(t)()
+		f.String("not-an-enum", "", "usage", zflag.OptEnumIgnoreCase())
+	}()
+
+	defer assertPanic(t)()
+	_ = f.MustGetEnum("s")
+}
+
+func TestEnumUsage(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Enum("format", "", []string{"json", "yaml"}, "output format")
+	f.Enum("level", "info", []string{"debug", "info", "warn"}, "log level", zflag.OptEnumDescriptions(map[string]string{
+		"debug": "everything",
+		"warn":  "only problems",
+	}))
+	f.Enum("color", "auto", []string{"auto", "always"}, "colorize `WHEN`")
+
+	expected := []string{
+		`      --color WHEN                colorize WHEN (default auto)`,
+		`      --format {json|yaml}        output format`,
+		`      --level {debug|info|warn}   log level (default info)`,
+		`                                    debug  everything`,
+		`                                    warn   only problems`,
+	}
+	assertEqual(t, strings.Join(expected, "\n")+"\n", f.FlagUsages())
+}
+
+func TestEnumChoiceValue(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Enum("level", "info", []string{"debug", "info"}, "usage", zflag.OptEnumDescriptions(map[string]string{"debug": "everything"}))
+
+	cv, ok := f.Lookup("level").Value.(zflag.ChoiceValue)
+	if !ok {
+		t.Fatal("expected enum flags to implement ChoiceValue")
+	}
+	assertDeepEqual(t, []string{"debug", "info"}, cv.Choices())
+	assertEqual(t, "everything", cv.ChoiceDescription("debug"))
+	assertEqual(t, "", cv.ChoiceDescription("info"))
+}
@@ -0,0 +1,77 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"os"
+	"strings"
+	"unicode"
+)
+
+// SetEnvPrefix enables reading flag values from environment variables for all
+// flags which do not have an explicit environment variable set with OptEnv.
+// The variable name is derived from the flag name, e.g. with the prefix "APP"
+// the flag "log-level" is read from APP_LOG_LEVEL. An empty prefix disables
+// the derived names again.
+func (fs *FlagSet) SetEnvPrefix(prefix string) {
+	fs.checkNotFrozen("set the env prefix")
+	fs.envPrefix = prefix
+	for _, flag := range fs.formal {
+		if flag.EnvVar == "" || flag.envVarDerived {
+			fs.deriveEnvVar(flag)
+		}
+	}
+}
+
+// SetEnvPrefix enables reading command-line flag values from environment variables.
+// See FlagSet.SetEnvPrefix for more information.
+func SetEnvPrefix(prefix string) {
+	CommandLine.SetEnvPrefix(prefix)
+}
+
+// deriveEnvVar sets the environment variable name of the flag based on the
+// env prefix of the FlagSet.
+func (fs *FlagSet) deriveEnvVar(flag *Flag) {
+	flag.EnvVar = ""
+	flag.envVarDerived = false
+	if fs.envPrefix == "" {
+		return
+	}
+
+	flag.EnvVar = envVarName(fs.envPrefix + "_" + flag.Name)
+	flag.envVarDerived = true
+}
+
+// envVarName converts name to upper case and replaces every character
+// that is not a letter or a digit with an underscore.
+func envVarName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
+
+// envProvider provides flag values from the environment variables of the
+// flags. Empty variables are treated as unset.
+type envProvider struct{}
+
+var _ Provider = envProvider{}
+
+func (envProvider) Source() Source {
+	return SourceEnv
+}
+
+func (envProvider) Lookup(flag *Flag) ([]string, bool) {
+	if flag.EnvVar == "" {
+		return nil, false
+	}
+
+	value, ok := os.LookupEnv(flag.EnvVar)
+	if !ok || value == "" {
+		return nil, false
+	}
+	return []string{value}, true
+}
@@ -0,0 +1,211 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestEnv(t *testing.T) {
+	tests := []struct {
+		name            string
+		env             map[string]string
+		prefix          string
+		opts            []zflag.Opt
+		args            []string
+		expectedErr     string
+		expectedValue   int
+		expectedChanged bool
+	}{
+		{
+			name:          "default when unset",
+			opts:          []zflag.Opt{zflag.OptEnv("ZFLAG_TEST_ENV_UNSET")},
+			expectedValue: 8080,
+		},
+		{
+			name:          "explicit env var",
+			env:           map[string]string{"ZFLAG_TEST_ENV_EXPLICIT": "9000"},
+			opts:          []zflag.Opt{zflag.OptEnv("ZFLAG_TEST_ENV_EXPLICIT")},
+			expectedValue: 9000,
+		},
+		{
+			name:          "empty env var is ignored",
+			env:           map[string]string{"ZFLAG_TEST_ENV_EMPTY": ""},
+			opts:          []zflag.Opt{zflag.OptEnv("ZFLAG_TEST_ENV_EMPTY")},
+			expectedValue: 8080,
+		},
+		{
+			name:          "derived from prefix",
+			env:           map[string]string{"ZFLAG_TEST_PREFIX_HTTP_PORT": "9001"},
+			prefix:        "ZFLAG_TEST_PREFIX",
+			expectedValue: 9001,
+		},
+		{
+			name:          "explicit env var wins over prefix",
+			env:           map[string]string{"ZFLAG_TEST_PREFIX2_HTTP_PORT": "9002", "ZFLAG_TEST_ENV_OVERRIDE": "9003"},
+			prefix:        "ZFLAG_TEST_PREFIX2",
+			opts:          []zflag.Opt{zflag.OptEnv("ZFLAG_TEST_ENV_OVERRIDE")},
+			expectedValue: 9003,
+		},
+		{
+			name:            "command line wins over env",
+			env:             map[string]string{"ZFLAG_TEST_ENV_CLI": "9004"},
+			opts:            []zflag.Opt{zflag.OptEnv("ZFLAG_TEST_ENV_CLI")},
+			args:            []string{"--http-port=9005"},
+			expectedValue:   9005,
+			expectedChanged: true,
+		},
+		{
+			name:        "invalid value",
+			env:         map[string]string{"ZFLAG_TEST_ENV_INVALID": "abc"},
+			opts:        []zflag.Opt{zflag.OptEnv("ZFLAG_TEST_ENV_INVALID")},
+			expectedErr: `environment variable ZFLAG_TEST_ENV_INVALID: invalid argument "abc" for "--http-port" flag: strconv.ParseInt: parsing "abc": invalid syntax`,
+		},
+	}
+
+	for _, test := range tests {
+		for k, v := range test.env {
+			k := k
+			os.Setenv(k, v)
+			t.Cleanup(func() { os.Unsetenv(k) })
+		}
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.SetEnvPrefix(test.prefix)
+			port := f.Int("http-port", 8080, "usage", test.opts...)
+
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedValue, *port)
+			assertEqual(t, test.expectedChanged, f.Changed("http-port"))
+		})
+	}
+}
+
+func TestEnvSlice(t *testing.T) {
+	os.Setenv("ZFLAG_TEST_ENV_SLICE", "from-env")
+	defer os.Unsetenv("ZFLAG_TEST_ENV_SLICE")
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	ss := f.StringSlice("ss", []string{"default"}, "usage", zflag.OptEnv("ZFLAG_TEST_ENV_SLICE"))
+	assertNoErr(t, f.Parse([]string{}))
+	assertDeepEqual(t, []string{"from-env"}, *ss)
+
+	f = zflag.NewFlagSet("test", zflag.ContinueOnError)
+	ss = f.StringSlice("ss", []string{"default"}, "usage", zflag.OptEnv("ZFLAG_TEST_ENV_SLICE"))
+	assertNoErr(t, f.Parse([]string{"--ss=one", "--ss=two"}))
+	assertDeepEqual(t, []string{"one", "two"}, *ss)
+}
+
+func TestEnvRequired(t *testing.T) {
+	os.Setenv("ZFLAG_TEST_ENV_REQUIRED", "value")
+	defer os.Unsetenv("ZFLAG_TEST_ENV_REQUIRED")
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("required", "", "usage", zflag.OptRequired(), zflag.OptEnv("ZFLAG_TEST_ENV_REQUIRED"))
+	f.String("other", "", "usage", zflag.OptRequired(), zflag.OptEnv("ZFLAG_TEST_ENV_REQUIRED_UNSET"))
+	err := f.Parse([]string{})
+	assertErrMsg(t, `required flag(s) "--other" not set`, err)
+}
+
+func TestEnvNoInterspersed(t *testing.T) {
+	os.Setenv("ZFLAG_TEST_NO_INTERSPERSED_PORT", "9")
+	defer os.Unsetenv("ZFLAG_TEST_NO_INTERSPERSED_PORT")
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetInterspersed(false)
+	f.SetEnvPrefix("ZFLAG_TEST_NO_INTERSPERSED")
+	port := f.Int("port", 1, "usage")
+	f.String("required", "", "usage", zflag.OptRequired())
+
+	err := f.Parse([]string{"pos", "--port=2"})
+	assertErrMsg(t, `required flag(s) "--required" not set`, err)
+	assertEqual(t, 9, *port)
+	assertDeepEqual(t, []string{"pos", "--port=2"}, f.Args())
+}
+
+func TestEnvInvalidValue(t *testing.T) {
+	os.Setenv("ZFLAG_TEST_INVALID_PORT", "abc")
+	defer os.Unsetenv("ZFLAG_TEST_INVALID_PORT")
+
+	var exitCode int
+	zflag.SetExitFunc(func(code int) { exitCode = code })
+	defer zflag.SetExitFunc(os.Exit)
+
+	for _, args := range [][]string{{}, {"pos"}} {
+		for _, errorHandling := range []zflag.ErrorHandling{zflag.ContinueOnError, zflag.ExitOnError} {
+			exitCode = -1
+			var output bytes.Buffer
+			f := zflag.NewFlagSet("test", errorHandling)
+			f.SetOutput(&output)
+			f.SetEnvPrefix("ZFLAG_TEST_INVALID")
+			port := f.Int("port", 8080, "usage")
+
+			err := f.Parse(args)
+			expectedErr := `environment variable ZFLAG_TEST_INVALID_PORT: invalid argument "abc" for "--port" flag: strconv.ParseInt: parsing "abc": invalid syntax`
+			if errorHandling == zflag.ContinueOnError {
+				assertErrMsg(t, expectedErr, err)
+				assertEqual(t, -1, exitCode)
+			} else {
+				assertEqual(t, 2, exitCode)
+			}
+			assertEqual(t, 8080, *port)
+			assertEqual(t, true, strings.HasSuffix(output.String(), expectedErr+"\n"))
+		}
+	}
+}
+
+func TestEnvPrefixChanged(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("log-level", "", "usage")
+	f.String("explicit", "", "usage", zflag.OptEnv("EXPLICIT"))
+	f.SetEnvPrefix("APP")
+	f.String("log.file", "", "usage")
+
+	assertEqual(t, "APP_LOG_LEVEL", f.Lookup("log-level").EnvVar)
+	assertEqual(t, "APP_LOG_FILE", f.Lookup("log.file").EnvVar)
+	assertEqual(t, "EXPLICIT", f.Lookup("explicit").EnvVar)
+
+	f.SetEnvPrefix("")
+	assertEqual(t, "", f.Lookup("log-level").EnvVar)
+	assertEqual(t, "EXPLICIT", f.Lookup("explicit").EnvVar)
+}
+
+func TestEnvUsage(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetEnvPrefix("APP")
+	f.Int("port", 8080, "port to listen on")
+	f.String("name", "", "name of the app", zflag.OptEnv("NAME"))
+
+	expected := []string{
+		`      --name string   name of the app [$NAME]`,
+		`      --port int      port to listen on (default 8080) [$APP_PORT]`,
+	}
+	assertEqual(t, strings.Join(expected, "\n")+"\n", f.FlagUsages())
+}
+
+func TestOptEnvEmpty(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	defer assertPanic(t)()
+	f.String("name", "", "usage", zflag.OptEnv(""))
+}
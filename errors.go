@@ -18,6 +18,10 @@ func getFlagWithDashes(name string) string {
 }
 
 type UnknownFlagError struct {
+	Token       string   // Token is the argument containing the flag.
+	Index       int      // Index is the position of Token in the parsed arguments.
+	Suggestions []string // Suggestions are the flags, with dashes, with names close to the unknown flag.
+
 	name string
 }
 
@@ -28,7 +32,76 @@ func NewUnknownFlagError(name string) error {
 }
 
 func (e UnknownFlagError) Error() string {
-	return fmt.Sprintf("unknown flag: %s", getFlagWithDashes(e.name))
+	return withSuggestions(fmt.Sprintf("unknown flag: %s", getFlagWithDashes(e.name)), e.Suggestions)
+}
+
+// withSuggestions appends the suggested flags to the error message.
+func withSuggestions(msg string, suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return msg
+	case 1:
+		return fmt.Sprintf("%s, did you mean %s?", msg, suggestions[0])
+	default:
+		return fmt.Sprintf("%s, did you mean one of %s?", msg, strings.Join(suggestions, ", "))
+	}
+}
+
+type BadFlagSyntaxError struct {
+	Token string // Token is the argument with the bad syntax.
+	Index int    // Index is the position of Token in the parsed arguments.
+}
+
+var _ error = (*BadFlagSyntaxError)(nil)
+
+func (e BadFlagSyntaxError) Error() string {
+	return fmt.Sprintf("bad flag syntax: %s", e.Token)
+}
+
+type UnknownShorthandFlagError struct {
+	Flag        string   // Flag is the unknown shorthand, with a dash.
+	Token       string   // Token is the argument containing the shorthand.
+	Index       int      // Index is the position of Token in the parsed arguments.
+	Suggestions []string // Suggestions are the shorthands, with a dash, which differ from Flag only in case.
+
+	shorthand  rune
+	shorthands string
+}
+
+var _ error = (*UnknownShorthandFlagError)(nil)
+
+func (e UnknownShorthandFlagError) Error() string {
+	return withSuggestions(fmt.Sprintf("unknown shorthand flag: %q in -%s", e.shorthand, e.shorthands), e.Suggestions)
+}
+
+type FlagNeedsArgumentError struct {
+	Flag  string // Flag is the flag missing its argument, with dashes.
+	Token string // Token is the argument containing the flag.
+	Index int    // Index is the position of Token in the parsed arguments.
+
+	shorthand  rune
+	shorthands string
+}
+
+var _ error = (*FlagNeedsArgumentError)(nil)
+
+func (e FlagNeedsArgumentError) Error() string {
+	if e.shorthand != 0 {
+		return fmt.Sprintf("flag needs an argument: %q in -%s", e.shorthand, e.shorthands)
+	}
+	return fmt.Sprintf("flag needs an argument: %s", e.Token)
+}
+
+type FlagValueNotAllowedError struct {
+	Flag  string // Flag is the flag which does not take a value, with dashes.
+	Token string // Token is the argument containing the flag and the value.
+	Index int    // Index is the position of Token in the parsed arguments.
+}
+
+var _ error = (*FlagValueNotAllowedError)(nil)
+
+func (e FlagValueNotAllowedError) Error() string {
+	return fmt.Sprintf("flag cannot have a value: %s", e.Token)
 }
 
 type MissingFlagsError []string
@@ -40,15 +113,22 @@ func (e *MissingFlagsError) AddMissingFlag(f *Flag) {
 }
 
 func (e MissingFlagsError) Error() string {
-	flagNames := make([]string, 0, len(e))
-	for _, s := range e {
+	return fmt.Sprintf(`required flag(s) %s not set`, quoteFlagNames(e))
+}
+
+func quoteFlagNames(names []string) string {
+	flagNames := make([]string, 0, len(names))
+	for _, s := range names {
 		flagNames = append(flagNames, fmt.Sprintf("%q", s))
 	}
 
-	return fmt.Sprintf(`required flag(s) %s not set`, strings.Join(flagNames, `, `))
+	return strings.Join(flagNames, `, `)
 }
 
 type InvalidArgumentError struct {
+	Token string // Token is the argument containing the flag, if the value was given on the command line.
+	Index int    // Index is the position of Token in the parsed arguments.
+
 	flagName string
 	value    interface{}
 	err      error
@@ -58,8 +138,8 @@ var _ error = (*InvalidArgumentError)(nil)
 
 func NewInvalidArgumentError(err error, f *Flag, value interface{}) error {
 	var flagName string
-	if f.Shorthand != 0 && f.ShorthandDeprecated == "" {
-		flagName = fmt.Sprintf("-%c", f.Shorthand)
+	if shorthands := f.visibleShorthands(); len(shorthands) > 0 {
+		flagName = fmt.Sprintf("-%c", shorthands[0])
 		if !f.ShorthandOnly {
 			flagName = fmt.Sprintf("%s, --%s", flagName, f.Name)
 		}
@@ -81,3 +161,139 @@ func (e InvalidArgumentError) Error() string {
 func (e InvalidArgumentError) Unwrap() error {
 	return e.err
 }
+
+type AmbiguousFlagError struct {
+	Flag       string   // Flag is the abbreviated flag, with dashes.
+	Candidates []string // Candidates are the flags, with dashes, matched by the abbreviation.
+}
+
+var _ error = (*AmbiguousFlagError)(nil)
+
+func (e AmbiguousFlagError) Error() string {
+	return fmt.Sprintf("ambiguous flag: %s could match %s", e.Flag, strings.Join(e.Candidates, ", "))
+}
+
+type MutuallyExclusiveFlagsError struct {
+	Flags []string // Flags are the flags in the group.
+	Set   []string // Set are the flags of the group that were set.
+}
+
+var _ error = (*MutuallyExclusiveFlagsError)(nil)
+
+func (e MutuallyExclusiveFlagsError) Error() string {
+	return fmt.Sprintf("flag(s) %s are mutually exclusive, but %s were set", quoteFlagNames(e.Flags), quoteFlagNames(e.Set))
+}
+
+type RequiredTogetherFlagsError struct {
+	Flags   []string // Flags are the flags in the group.
+	Missing []string // Missing are the flags of the group that were not set.
+}
+
+var _ error = (*RequiredTogetherFlagsError)(nil)
+
+func (e RequiredTogetherFlagsError) Error() string {
+	return fmt.Sprintf("flag(s) %s must be set together, but %s not set", quoteFlagNames(e.Flags), quoteFlagNames(e.Missing))
+}
+
+type OneRequiredFlagsError struct {
+	Flags []string // Flags are the flags in the group.
+}
+
+var _ error = (*OneRequiredFlagsError)(nil)
+
+func (e OneRequiredFlagsError) Error() string {
+	return fmt.Sprintf("one of the flag(s) %s must be set", quoteFlagNames(e.Flags))
+}
+
+type ConditionallyRequiredFlagsError struct {
+	Missing   []string  // Missing are the required flags that were not set.
+	Condition Condition // Condition is the condition which made the flags required.
+	Unless    bool      // Unless is set when the flags were required because the condition was not met.
+}
+
+var _ error = (*ConditionallyRequiredFlagsError)(nil)
+
+func (e ConditionallyRequiredFlagsError) Error() string {
+	when := "when"
+	if e.Unless {
+		when = "unless"
+	}
+	return fmt.Sprintf("required flag(s) %s not set, they are required %s %s", quoteFlagNames(e.Missing), when, e.Condition)
+}
+
+type DeprecatedFlagError struct {
+	Flag    string // Flag is the deprecated flag or shorthand, with dashes.
+	Message string // Message is the deprecation notice.
+
+	shorthand bool
+}
+
+var _ error = (*DeprecatedFlagError)(nil)
+
+func (e DeprecatedFlagError) Error() string {
+	if e.shorthand {
+		return fmt.Sprintf("flag shorthand %s has been deprecated, %s", e.Flag, e.Message)
+	}
+	return fmt.Sprintf("flag %s has been deprecated, %s", e.Flag, e.Message)
+}
+
+type SourcePrecedenceError struct {
+	Flag    string // Flag is the flag whose value was ignored, with dashes.
+	Source  Source // Source is the source of the ignored value.
+	Current Source // Current is the source of the current value of the flag.
+}
+
+var _ error = (*SourcePrecedenceError)(nil)
+
+func (e SourcePrecedenceError) Error() string {
+	return fmt.Sprintf("value of flag %s from %s ignored: the value from %s has a higher precedence", e.Flag, e.Source, e.Current)
+}
+
+type FrozenFlagSetError struct {
+	FlagSet string // FlagSet is the name of the frozen FlagSet.
+	Flag    string // Flag is the flag which was set, with dashes, or empty if the FlagSet was reset.
+}
+
+var _ error = (*FrozenFlagSetError)(nil)
+
+func (e FrozenFlagSetError) Error() string {
+	if e.Flag == "" {
+		return fmt.Sprintf("unable to reset %q flagset: flagset is frozen", e.FlagSet)
+	}
+	return fmt.Sprintf("unable to set flag %s in %q flagset: flagset is frozen", e.Flag, e.FlagSet)
+}
+
+// ParseErrors contains all errors found while parsing, when
+// FlagSet.CollectErrors is set.
+type ParseErrors []error
+
+var _ error = (*ParseErrors)(nil)
+
+func (e ParseErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ParseErrors) Unwrap() []error {
+	return e
+}
+
+// ValidationErrors contains the errors of all failed checks of FlagSet.Validate.
+type ValidationErrors []error
+
+var _ error = (*ValidationErrors)(nil)
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	return e
+}
@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode/utf8"
 )
 
@@ -38,7 +39,34 @@ type ParseErrorsAllowList struct {
 	UnknownFlags bool
 	// RequiredFlags will ignore required flags errors and continue parsing rest of the flags
 	// See GetRequiredFlags to retrieve collected required flags.
+	// It also ignores the errors of RequiredIf and RequiredUnless.
 	RequiredFlags bool
+	// FlagGroups will ignore errors of flag groups, see MarkMutuallyExclusive,
+	// MarkRequiredTogether and MarkOneRequired. RequiredFlags ignores the
+	// errors of required together and one required groups as well.
+	FlagGroups bool
+	// InvalidValues will ignore invalid value errors of the named flags on the
+	// command line, or of all flags if it contains "*". Whether the flags keep
+	// their previous value depends on their Value. See GetIgnoredErrors to
+	// retrieve the ignored errors.
+	InvalidValues []string
+	// MissingArguments will ignore errors of flags missing their argument on the
+	// command line. The flags are not set. See GetIgnoredErrors to retrieve the
+	// ignored errors.
+	MissingArguments bool
+	// DeprecatedFlags will silence the deprecation notices of flags and shorthands.
+	// See GetIgnoredErrors to retrieve the deprecated flags which were used.
+	DeprecatedFlags bool
+}
+
+// allowsInvalidValue returns whether invalid values of the flag are ignored.
+func (fs *FlagSet) allowsInvalidValue(flag *Flag) bool {
+	for _, name := range fs.ParseErrorsAllowList.InvalidValues {
+		if name == "*" || fs.lookup(fs.normalizeFlagName(name)) == flag {
+			return true
+		}
+	}
+	return false
 }
 
 // NormalizedName is a flag name that has been normalized according to rules
@@ -62,6 +90,28 @@ type FlagSet struct {
 	// DisableBuiltinHelp toggles the built-in convention of handling -h and --help
 	DisableBuiltinHelp bool
 
+	// AllowAbbreviations allows long flags to be abbreviated to any unique
+	// prefix of their name, e.g. --verb for --verbose.
+	AllowAbbreviations bool
+
+	// SuggestionsMaxDistance is the maximum edit distance between an unknown
+	// flag and the flags suggested instead. Zero means 2, a negative value
+	// disables suggestions.
+	SuggestionsMaxDistance int
+
+	// EnableResponseFiles expands arguments of the form @file into the arguments
+	// read from file. Use @@ to pass an argument starting with @ literally. The
+	// values of flags, also when given as a separate argument, are never
+	// expanded or unescaped.
+	EnableResponseFiles bool
+
+	// CollectErrors keeps parsing after an invalid argument, and returns all
+	// errors, including those of Validate, together as ParseErrors.
+	CollectErrors bool
+
+	// FreezeAfterParse freezes the FlagSet when parsing is done, see Freeze.
+	FreezeAfterParse bool
+
 	// FlagUsageFormatter allows for custom formatting of flag usage output.
 	// Each individual item needs to be implemented. See FlagUsagesForGroupWrapped for info on what gets passed.
 	FlagUsageFormatter FlagUsageFormatter
@@ -75,36 +125,66 @@ type FlagSet struct {
 	orderedFormal     []*Flag
 	sortedFormal      []*Flag
 	shorthands        map[rune]*Flag
+	aliases           map[NormalizedName]*Flag
 	args              []string // arguments after flags
 	argsLenAtDash     int      // len(args) when a '--' was located when parsing, or -1 if no --
+	argToken          string   // argument being parsed
+	argIndex          int      // index in the parsed arguments of argToken
 	errorHandling     ErrorHandling
 	output            io.Writer // nil means stderr; use Output() accessor
 	interspersed      bool      // Allow interspersed option/non-option args
 	normalizeNameFunc func(f *FlagSet, name string) NormalizedName
+	envPrefix         string     // prefix used to derive environment variable names of flags
+	providers         []Provider // providers consulted for flag values after parsing the arguments
+	flagGroups        []flagGroup
+	requirements      []conditionalRequirement
+	validators        []func(*FlagSet) error
+	sourcePrecedence  []Source      // order of precedence of the sources, nil means defaultSourcePrecedence
+	mu                *sync.RWMutex // mu synchronizes the values of the flags, nil unless concurrency safe
+	frozen            bool          // frozen is set by Freeze
 
 	addedGoFlagSets []*goflag.FlagSet
 	unknownFlags    []string
+	ignoredErrors   []error
+
+	tokenizing         bool                // tokenizing is set on the copy of the FlagSet used by Tokenize.
+	tokens             []Token             // tokens are the tokens found by Tokenize.
+	pendingOccurrences []pendingOccurrence // pendingOccurrences are the flags in the argument being tokenized.
 }
 
 // A Flag represents the state of a flag.
 type Flag struct {
-	Name                string              // Name as it appears on command line.
-	Shorthand           rune                // Shorthand represents a one-letter abbreviation of a flag.
-	ShorthandOnly       bool                // ShorthandOnly specifies if the user set only the shorthand.
-	Usage               string              // Usage should contain the help message.
-	UsageType           string              // UsageType is the flag type displayed in the help message.
-	DisableUnquoteUsage bool                // DisableUnquoteUsage will toggle extract and unquote the type from the usage.
-	DisablePrintDefault bool                // DisablePrintDefault toggles printing of the default value in usage message.
-	Value               Value               // Value of the value as set.
-	AddNegative         bool                // AddNegative automatically add a --no-<flag> option for boolean flags.
-	DefValue            string              // DefValue should contain the default value (as text); for usage message.
-	Changed             bool                // Changed contains whether the user set the value (or if left to default).
-	Deprecated          string              // Deprecated is a string printed for a deprecation notice.
-	Hidden              bool                // Hidden is used by zulu.Command to allow flags to be hidden from help/usage text.
-	Required            bool                // Required ensures that a flag must be changed.
-	ShorthandDeprecated string              // ShorthandDeprecated is a string printed for a deprecation notice of the Shorthand.
-	Group               string              // Group contains the flag group.
-	Annotations         map[string][]string // Annotations are used to annotate this specific flag for your application; e.g. it is used by zulu.Command bash completion code.
+	Name                 string                    // Name as it appears on command line.
+	Shorthand            rune                      // Shorthand represents a one-letter abbreviation of a flag.
+	ShorthandOnly        bool                      // ShorthandOnly specifies if the user set only the shorthand.
+	Usage                string                    // Usage should contain the help message.
+	UsageType            string                    // UsageType is the flag type displayed in the help message.
+	DisableUnquoteUsage  bool                      // DisableUnquoteUsage will toggle extract and unquote the type from the usage.
+	DisablePrintDefault  bool                      // DisablePrintDefault toggles printing of the default value in usage message.
+	Value                Value                     // Value of the value as set.
+	AddNegative          bool                      // AddNegative automatically add a --no-<flag> option for boolean flags.
+	DefValue             string                    // DefValue should contain the default value (as text); for usage message.
+	Changed              bool                      // Changed contains whether the user set the value (or if left to default).
+	Deprecated           string                    // Deprecated is a string printed for a deprecation notice.
+	Hidden               bool                      // Hidden is used by zulu.Command to allow flags to be hidden from help/usage text.
+	Required             bool                      // Required ensures that a flag must be changed.
+	ShorthandDeprecated  string                    // ShorthandDeprecated is a string printed for a deprecation notice of the Shorthand.
+	Shorthands           []rune                    // Shorthands are additional one-letter abbreviations of the flag.
+	ShorthandsDeprecated map[rune]string           // ShorthandsDeprecated contains deprecation notices of individual shorthands.
+	Group                string                    // Group contains the flag group.
+	Annotations          map[string][]string       // Annotations are used to annotate this specific flag for your application; e.g. it is used by zulu.Command bash completion code.
+	EnvVar               string                    // EnvVar is the environment variable the value is read from when the flag is not set on the command line.
+	Aliases              []string                  // Aliases are additional long names of the flag.
+	ShowAliases          bool                      // ShowAliases lists the aliases of the flag in the help message.
+	NoOptDefVal          string                    // NoOptDefVal is the value set when the flag is passed without a value, which makes the value optional.
+	Choices              []string                  // Choices are the values the flag accepts, checked after the value is set.
+	Validators           []func(interface{}) error // Validators validate the value of the flag after it is set.
+	AllowDashValue       bool                      // AllowDashValue allows the value of the flag to start with a dash when passed as a separate argument.
+	Mutable              bool                      // Mutable allows the flag to be set after the FlagSet is frozen.
+
+	envVarDerived bool         // envVarDerived is set when EnvVar was derived from the env prefix of the FlagSet.
+	source        Source       // source records where the current value was read from.
+	occurrences   []Occurrence // occurrences records the appearances of the flag on the command line.
 }
 
 // Value is the interface to the dynamic value stored in a flag.
@@ -124,6 +204,32 @@ type Typed interface {
 	Type() string
 }
 
+// ChoiceValue is an interface of Values that only accept a fixed set of values.
+// It allows e.g. completion generators to query the allowed values.
+type ChoiceValue interface {
+	Value
+	// Choices returns the values the flag accepts.
+	Choices() []string
+	// ChoiceDescription returns the description of the given choice, or an
+	// empty string if it has none.
+	ChoiceDescription(choice string) string
+}
+
+// Cloner is implemented by values which can be copied, which is needed to
+// clone a FlagSet or to parse its flags into a ParseResult.
+type Cloner interface {
+	// Clone returns a copy of the value, with its own storage.
+	Clone() Value
+}
+
+// Resetter is implemented by values which can restore the value they were
+// created with. Values which do not implement Resetter are reset by setting
+// the default value of the flag.
+type Resetter interface {
+	// Reset restores the default value, and forgets that the value was set.
+	Reset()
+}
+
 // SliceValue is a secondary interface to all flags which hold a list
 // of values.  This allows full control over the value of list flags,
 // and avoids complicated marshalling and unmarshalling to csv.
@@ -136,6 +242,16 @@ type SliceValue interface {
 	GetSlice() []string
 }
 
+// mapValue is implemented by the values of map flags, which can be set from
+// a config or a provider without being marked as set, so that a value from
+// the command line replaces the map instead of being added to it.
+type mapValue interface {
+	Value
+	// replace sets the map to the key=value entries, added to the current
+	// entries if merge is true.
+	replace(entries []string, merge bool) error
+}
+
 // BoolFlag is an optional interface to indicate boolean flags that can be
 // supplied without a value text
 type BoolFlag interface {
@@ -170,6 +286,7 @@ func sortFlags(flags map[NormalizedName]*Flag) []*Flag {
 // a flag named "getURL" and have it translated to "geturl".  A user could then pass
 // "--getUrl" which may also be translated to "geturl" and everything will work.
 func (fs *FlagSet) SetNormalizeFunc(n func(f *FlagSet, name string) NormalizedName) {
+	fs.checkNotFrozen("set the normalize func")
 	fs.normalizeNameFunc = n
 	fs.sortedFormal = fs.sortedFormal[:0]
 	for fname, flag := range fs.formal {
@@ -178,6 +295,9 @@ func (fs *FlagSet) SetNormalizeFunc(n func(f *FlagSet, name string) NormalizedNa
 			continue
 		}
 		flag.Name = string(nname)
+		if flag.envVarDerived {
+			fs.deriveEnvVar(flag)
+		}
 		delete(fs.formal, fname)
 		fs.formal[nname] = flag
 		if _, set := fs.actual[fname]; set {
@@ -185,6 +305,17 @@ func (fs *FlagSet) SetNormalizeFunc(n func(f *FlagSet, name string) NormalizedNa
 			fs.actual[nname] = flag
 		}
 	}
+
+	if len(fs.aliases) > 0 {
+		fs.aliases = make(map[NormalizedName]*Flag, len(fs.aliases))
+		for _, flag := range fs.formal {
+			for i, alias := range flag.Aliases {
+				nname := fs.normalizeFlagName(alias)
+				flag.Aliases[i] = string(nname)
+				fs.aliases[nname] = flag
+			}
+		}
+	}
 }
 
 // GetNormalizeFunc returns the previously set NormalizeFunc of a function which
@@ -225,6 +356,7 @@ func (fs *FlagSet) SetOutput(output io.Writer) {
 // in primordial order if f.SortFlags is false.
 // It visits all flags, even those not set.
 func (fs *FlagSet) GetAllFlags() (flags []*Flag) {
+	defer fs.lock()()
 	if fs.SortFlags {
 		if len(fs.formal) != len(fs.sortedFormal) {
 			fs.sortedFormal = sortFlags(fs.formal)
@@ -238,11 +370,10 @@ func (fs *FlagSet) GetAllFlags() (flags []*Flag) {
 
 // VisitAll visits the flags in lexicographical order or
 // in primordial order if f.SortFlags is false, calling fn for each.
-// It visits all flags, even those not set.
+// It visits all flags, even those not set. fn is called without the FlagSet
+// being locked, so it can use the FlagSet, e.g. ValueString to read the value
+// of a flag which is concurrently set.
 func (fs *FlagSet) VisitAll(fn func(*Flag)) {
-	if len(fs.formal) == 0 {
-		return
-	}
 	for _, flag := range fs.GetAllFlags() {
 		fn(flag)
 	}
@@ -281,6 +412,7 @@ func VisitAll(fn func(*Flag)) {
 // in primordial order if f.SortFlags is false.
 // It visits only those flags that have been set.
 func (fs *FlagSet) GetFlags() (flags []*Flag) {
+	defer fs.lock()()
 	if fs.SortFlags {
 		if len(fs.actual) != len(fs.sortedActual) {
 			fs.sortedActual = sortFlags(fs.actual)
@@ -294,11 +426,10 @@ func (fs *FlagSet) GetFlags() (flags []*Flag) {
 
 // Visit visits the flags in lexicographical order or
 // in primordial order if f.SortFlags is false, calling fn for each.
-// It visits only those flags that have been set.
+// It visits only those flags that have been set. fn is called without the
+// FlagSet being locked, so it can use the FlagSet, e.g. ValueString to read
+// the value of a flag which is concurrently set.
 func (fs *FlagSet) Visit(fn func(*Flag)) {
-	if len(fs.actual) == 0 {
-		return
-	}
 	for _, flag := range fs.GetFlags() {
 		fn(flag)
 	}
@@ -335,6 +466,28 @@ func GetUnknownFlags() []string {
 	return CommandLine.GetUnknownFlags()
 }
 
+// ignoreOrFail records err as ignored if ignore is set, or else fails with err.
+func (fs *FlagSet) ignoreOrFail(err error, ignore bool) error {
+	if ignore {
+		fs.ignoredErrors = append(fs.ignoredErrors, err)
+		return nil
+	}
+	return fs.failf("%w", err)
+}
+
+// GetIgnoredErrors returns the errors ignored because of the InvalidValues,
+// MissingArguments and DeprecatedFlags categories of ParseErrorsAllowList,
+// and the SourcePrecedenceError of values from the command line which were
+// ignored, in the order they occurred.
+func (fs *FlagSet) GetIgnoredErrors() []error {
+	return fs.ignoredErrors
+}
+
+// GetIgnoredErrors returns the errors ignored while parsing the command-line flags.
+func GetIgnoredErrors() []error {
+	return CommandLine.GetIgnoredErrors()
+}
+
 // Get returns the value of the named flag.
 func (fs *FlagSet) Get(name string) (interface{}, error) {
 	return fs.getFlagValue(name, "")
@@ -345,8 +498,26 @@ func Get(name string) (interface{}, error) {
 	return CommandLine.Get(name)
 }
 
+// ValueString returns the string representation of the value of the named
+// flag. Unlike Flag.Value.String, it can be used while the flag is set
+// concurrently.
+func (fs *FlagSet) ValueString(name string) (string, error) {
+	defer fs.rlock()()
+	flag := fs.lookup(fs.normalizeFlagName(name))
+	if flag == nil {
+		return "", NewUnknownFlagError(name)
+	}
+	return flag.Value.String(), nil
+}
+
+// ValueString returns the string representation of the value of the named command-line flag.
+func ValueString(name string) (string, error) {
+	return CommandLine.ValueString(name)
+}
+
 // Lookup returns the Flag structure of the named flag, returning nil if none exists.
 func (fs *FlagSet) Lookup(name string) *Flag {
+	defer fs.rlock()()
 	return fs.lookup(fs.normalizeFlagName(name))
 }
 
@@ -390,12 +561,16 @@ func shorthandStrToRune(name string) (rune, error) {
 
 // lookup returns the Flag structure of the named flag, returning nil if none exists.
 func (fs *FlagSet) lookup(name NormalizedName) *Flag {
-	return fs.formal[name]
+	if flag, ok := fs.formal[name]; ok {
+		return flag
+	}
+	return fs.aliases[name]
 }
 
 // getFlagValue returns the value of a flag based on the requested name and type.
 func (fs *FlagSet) getFlagValue(name string, fType string) (interface{}, error) {
-	flag := fs.Lookup(name)
+	defer fs.rlock()()
+	flag := fs.lookup(fs.normalizeFlagName(name))
 	if flag == nil {
 		return nil, NewUnknownFlagError(name)
 	}
@@ -439,33 +614,82 @@ func ShorthandLookupStr(name string) *Flag {
 
 // Set sets the value of the named flag.
 func (fs *FlagSet) Set(name, value string) error {
-	normalName := fs.normalizeFlagName(name)
-	flag, ok := fs.formal[normalName]
-	if !ok {
-		return NewUnknownFlagError(name)
+	return fs.set(name, value, SourceSet)
+}
+
+// set sets the value of the named flag and marks it as changed, unless the
+// current value was read from a source with a higher precedence.
+func (fs *FlagSet) set(name, value string, source Source) error {
+	flag, err := fs.lookupSettable(name, source)
+	if flag == nil {
+		return err
 	}
 
-	err := flag.Value.Set(value)
+	err = fs.setValue(flag, func(v Value) error {
+		return v.Set(value)
+	})
 	if err != nil {
 		return NewInvalidArgumentError(err, flag, value)
 	}
 
+	defer fs.lock()()
+	flag.source = source
 	if !flag.Changed {
 		if fs.actual == nil {
 			fs.actual = make(map[NormalizedName]*Flag)
 		}
-		fs.actual[normalName] = flag
+		fs.actual[NormalizedName(flag.Name)] = flag
 		fs.orderedActual = append(fs.orderedActual, flag)
 
 		flag.Changed = true
 	}
 
 	if flag.Deprecated != "" {
-		fmt.Fprintf(fs.Output(), "Flag --%s has been deprecated, %s\n", flag.Name, flag.Deprecated)
+		fs.deprecationNotice(DeprecatedFlagError{Flag: "--" + flag.Name, Message: flag.Deprecated})
 	}
 	return nil
 }
 
+// lookupSettable returns the named flag if its value can be set from source.
+// It returns no flag if the current value was read from a source with a
+// higher precedence, together with an error if the source is SourceSet.
+func (fs *FlagSet) lookupSettable(name string, source Source) (*Flag, error) {
+	defer fs.lock()()
+	flag := fs.lookup(fs.normalizeFlagName(name))
+	if flag == nil {
+		return nil, NewUnknownFlagError(name)
+	}
+
+	if err := fs.checkSettable(flag); err != nil {
+		return nil, err
+	}
+
+	if !fs.overrides(source, flag) {
+		err := SourcePrecedenceError{Flag: getFlagWithDashes(flag.Name), Source: source, Current: flag.Source()}
+		if source == SourceSet {
+			return nil, err
+		}
+		fs.ignoredErrors = append(fs.ignoredErrors, err)
+		return nil, nil
+	}
+
+	return flag, nil
+}
+
+// deprecationNotice prints the deprecation notice, or records it as an
+// ignored error if deprecated flags are allowed.
+func (fs *FlagSet) deprecationNotice(err DeprecatedFlagError) {
+	if fs.ParseErrorsAllowList.DeprecatedFlags {
+		fs.ignoredErrors = append(fs.ignoredErrors, err)
+		return
+	}
+	if err.shorthand {
+		fmt.Fprintf(fs.Output(), "Flag shorthand %s has been deprecated, %s\n", err.Flag, err.Message)
+		return
+	}
+	fmt.Fprintf(fs.Output(), "Flag %s has been deprecated, %s\n", err.Flag, err.Message)
+}
+
 // SetAnnotation allows one to set arbitrary annotations on this flag.
 // This is sometimes used by zulucmd/zulu programs which want to generate additional
 // bash completion information.
@@ -480,7 +704,8 @@ func (f *Flag) SetAnnotation(key string, values []string) {
 // Changed returns true if the flag was explicitly set during Parse() and false
 // otherwise
 func (fs *FlagSet) Changed(name string) bool {
-	flag := fs.Lookup(name)
+	defer fs.rlock()()
+	flag := fs.lookup(fs.normalizeFlagName(name))
 	// If a flag doesn't exist, it wasn't changed....
 	if flag == nil {
 		return false
@@ -513,7 +738,7 @@ func (f *Flag) DefaultIsZeroValue() bool {
 		return f.DefValue == "0s"
 	case *intValue, *int8Value, *int32Value, *int64Value, *uintValue, *uint8Value, *uint16Value, *uint32Value, *uint64Value, *countValue, *float32Value, *float64Value:
 		return f.DefValue == "0"
-	case *stringValue:
+	case *stringValue, *enumValue:
 		return f.DefValue == ""
 	case *ipValue, *ipMaskValue, *ipNetValue:
 		return f.DefValue == "<nil>"
@@ -540,6 +765,10 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 		name, usage = unquoteBacktickFromUsage(name, usage)
 	}
 
+	if cv, ok := flag.Value.(ChoiceValue); ok && name == "" {
+		name = "{" + strings.Join(cv.Choices(), "|") + "}"
+	}
+
 	if name == "" {
 		name = "value" // compatibility layer to be a drop-in replacement
 		if v, ok := flag.Value.(Typed); ok {
@@ -817,7 +1046,10 @@ var Usage = func() {
 }
 
 // NFlag returns the number of flags that have been set.
-func (fs *FlagSet) NFlag() int { return len(fs.actual) }
+func (fs *FlagSet) NFlag() int {
+	defer fs.rlock()()
+	return len(fs.actual)
+}
 
 // NFlag returns the number of command-line flags that have been set.
 func NFlag() int { return len(CommandLine.actual) }
@@ -873,14 +1105,15 @@ func (fs *FlagSet) Var(value Value, name, usage string, opts ...Opt) *Flag {
 
 // AddFlag will add the flag to the FlagSet
 func (fs *FlagSet) AddFlag(flag *Flag) {
+	defer fs.lock()()
+	fs.checkNotFrozen(fmt.Sprintf("add flag %q", flag.Name))
+	fs.addFlag(flag)
+}
+
+func (fs *FlagSet) addFlag(flag *Flag) {
 	normalizedFlagName := fs.normalizeFlagName(flag.Name)
+	normalizedAliases := fs.checkRedefined(flag, normalizedFlagName, nil)
 
-	_, alreadyThere := fs.formal[normalizedFlagName]
-	if alreadyThere {
-		msg := fmt.Sprintf("%s flag redefined: %s", fs.name, flag.Name)
-		fmt.Fprintln(fs.Output(), msg)
-		panic(msg) // Happens only if flags are declared with identical names
-	}
 	if fs.formal == nil {
 		fs.formal = make(map[NormalizedName]*Flag)
 	}
@@ -889,28 +1122,265 @@ func (fs *FlagSet) AddFlag(flag *Flag) {
 	fs.formal[normalizedFlagName] = flag
 	fs.orderedFormal = append(fs.orderedFormal, flag)
 
-	if flag.Shorthand == 0 {
+	if len(normalizedAliases) > 0 && fs.aliases == nil {
+		fs.aliases = make(map[NormalizedName]*Flag)
+	}
+	for i, alias := range normalizedAliases {
+		flag.Aliases[i] = string(alias)
+		fs.aliases[alias] = flag
+	}
+
+	if flag.EnvVar == "" {
+		fs.deriveEnvVar(flag)
+	}
+
+	shorthands := flag.allShorthands()
+	if len(shorthands) == 0 {
 		return
 	}
 	if fs.shorthands == nil {
 		fs.shorthands = make(map[rune]*Flag)
 	}
-	used, alreadyThere := fs.shorthands[flag.Shorthand]
-	if alreadyThere {
-		msg := fmt.Sprintf("unable to redefine %q shorthand in %q flagset: it's already used for %q flag", flag.Shorthand, fs.name, used.Name)
+	for _, shorthand := range shorthands {
+		fs.shorthands[shorthand] = flag
+	}
+}
+
+// checkRedefined panics if the name, aliases or shorthands of flag are already
+// used, by itself or by any flag other than ignored. It returns the normalized
+// aliases of the flag.
+func (fs *FlagSet) checkRedefined(flag *Flag, normalizedFlagName NormalizedName, ignored *Flag) []NormalizedName {
+	isUsed := func(name NormalizedName) bool {
+		used := fs.lookup(name)
+		return used != nil && used != ignored
+	}
+
+	if isUsed(normalizedFlagName) {
+		msg := fmt.Sprintf("%s flag redefined: %s", fs.name, flag.Name)
 		fmt.Fprintln(fs.Output(), msg)
-		panic(msg)
+		panic(msg) // Happens only if flags are declared with identical names
+	}
+
+	normalizedAliases := make([]NormalizedName, len(flag.Aliases))
+	for i, alias := range flag.Aliases {
+		normalizedAliases[i] = fs.normalizeFlagName(alias)
+		duplicate := normalizedAliases[i] == normalizedFlagName || isUsed(normalizedAliases[i])
+		for _, a := range normalizedAliases[:i] {
+			duplicate = duplicate || a == normalizedAliases[i]
+		}
+		if duplicate {
+			msg := fmt.Sprintf("%s flag redefined: %s", fs.name, alias)
+			fmt.Fprintln(fs.Output(), msg)
+			panic(msg)
+		}
+	}
+
+	shorthands := flag.allShorthands()
+	for i, shorthand := range shorthands {
+		used, alreadyThere := fs.shorthands[shorthand]
+		if !alreadyThere || used == ignored {
+			used = nil
+		}
+		for _, s := range shorthands[:i] {
+			if s == shorthand {
+				used = flag
+			}
+		}
+		if used != nil {
+			msg := fmt.Sprintf("unable to redefine %q shorthand in %q flagset: it's already used for %q flag", shorthand, fs.name, used.Name)
+			fmt.Fprintln(fs.Output(), msg)
+			panic(msg)
+		}
 	}
-	fs.shorthands[flag.Shorthand] = flag
+
+	return normalizedAliases
 }
 
-// RemoveFlag will remove the flag from the FlagSet
+// allShorthands returns the shorthand and the additional shorthands of the flag.
+func (f *Flag) allShorthands() []rune {
+	var shorthands []rune
+	if f.Shorthand != 0 {
+		shorthands = append(shorthands, f.Shorthand)
+	}
+	for _, shorthand := range f.Shorthands {
+		if shorthand != 0 {
+			shorthands = append(shorthands, shorthand)
+		}
+	}
+	return shorthands
+}
+
+// visibleShorthands returns the shorthands of the flag which are not deprecated.
+func (f *Flag) visibleShorthands() []rune {
+	var shorthands []rune
+	for _, shorthand := range f.allShorthands() {
+		if f.shorthandDeprecation(shorthand) == "" {
+			shorthands = append(shorthands, shorthand)
+		}
+	}
+	return shorthands
+}
+
+// shorthandDeprecation returns the deprecation notice of the given shorthand.
+func (f *Flag) shorthandDeprecation(shorthand rune) string {
+	if shorthand == f.Shorthand && f.ShorthandDeprecated != "" {
+		return f.ShorthandDeprecated
+	}
+	return f.ShorthandsDeprecated[shorthand]
+}
+
+// hasShorthand returns whether the given rune is one of the shorthands of the flag.
+func (f *Flag) hasShorthand(shorthand rune) bool {
+	for _, s := range f.allShorthands() {
+		if s == shorthand {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveFlag will remove the flag from the FlagSet, together with its
+// aliases and shorthands. The flag is also removed from the flag groups and
+// conditional requirements it is part of. Removing a flag which does not
+// exist does nothing.
 func (fs *FlagSet) RemoveFlag(name string) {
-	normalizedFlagName := fs.normalizeFlagName(name)
-	_, exists := fs.formal[normalizedFlagName]
-	if exists {
-		delete(fs.formal, normalizedFlagName)
+	defer fs.lock()()
+	fs.checkNotFrozen(fmt.Sprintf("remove flag %q", name))
+	flag := fs.lookup(fs.normalizeFlagName(name))
+	if flag == nil {
+		return
 	}
+
+	fs.removeFlag(flag)
+	fs.orderedFormal = removeFromFlags(fs.orderedFormal, flag)
+	fs.replaceInConstraints(flag, nil)
+}
+
+// ReplaceFlag replaces the definition of the named flag with flag, keeping
+// its position in the help message and in the flag groups and conditional
+// requirements. The new flag is added like with AddFlag, and may have a
+// different name. It panics if the named flag does not exist.
+func (fs *FlagSet) ReplaceFlag(name string, flag *Flag) {
+	defer fs.lock()()
+	fs.checkNotFrozen(fmt.Sprintf("replace flag %q", name))
+	old := fs.lookup(fs.normalizeFlagName(name))
+	if old == nil {
+		msg := fmt.Sprintf("unable to replace %q in %q flagset: flag does not exist", name, fs.name)
+		fmt.Fprintln(fs.Output(), msg)
+		panic(msg)
+	}
+
+	fs.checkRedefined(flag, fs.normalizeFlagName(flag.Name), old)
+	fs.removeFlag(old)
+	fs.addFlag(flag)
+	fs.orderedFormal = fs.orderedFormal[:len(fs.orderedFormal)-1]
+	for i, f := range fs.orderedFormal {
+		if f == old {
+			fs.orderedFormal[i] = flag
+		}
+	}
+	fs.replaceInConstraints(old, flag)
+}
+
+// RenameFlag renames the named flag to newName, keeping its value, aliases,
+// shorthands and position. It panics if the named flag does not exist, or if
+// newName is already used.
+func (fs *FlagSet) RenameFlag(name, newName string) {
+	defer fs.lock()()
+	fs.checkNotFrozen(fmt.Sprintf("rename flag %q", name))
+	flag := fs.lookup(fs.normalizeFlagName(name))
+	if flag == nil {
+		msg := fmt.Sprintf("unable to rename %q in %q flagset: flag does not exist", name, fs.name)
+		fmt.Fprintln(fs.Output(), msg)
+		panic(msg)
+	}
+	normalizedNewName := fs.normalizeFlagName(newName)
+	if fs.lookup(normalizedNewName) != nil {
+		msg := fmt.Sprintf("%s flag redefined: %s", fs.name, newName)
+		fmt.Fprintln(fs.Output(), msg)
+		panic(msg)
+	}
+
+	oldName := NormalizedName(flag.Name)
+	flag.Name = string(normalizedNewName)
+	if flag.envVarDerived {
+		fs.deriveEnvVar(flag)
+	}
+	delete(fs.formal, oldName)
+	fs.formal[normalizedNewName] = flag
+	if _, set := fs.actual[oldName]; set {
+		delete(fs.actual, oldName)
+		fs.actual[normalizedNewName] = flag
+	}
+	fs.sortedFormal = nil
+	fs.sortedActual = nil
+}
+
+// removeFlag removes the flag from the lookup maps and the set flags, but not
+// from orderedFormal, so that ReplaceFlag can keep its position.
+func (fs *FlagSet) removeFlag(flag *Flag) {
+	delete(fs.formal, NormalizedName(flag.Name))
+	for _, alias := range flag.Aliases {
+		delete(fs.aliases, NormalizedName(alias))
+	}
+	for _, shorthand := range flag.allShorthands() {
+		if fs.shorthands[shorthand] == flag {
+			delete(fs.shorthands, shorthand)
+		}
+	}
+	if _, set := fs.actual[NormalizedName(flag.Name)]; set {
+		delete(fs.actual, NormalizedName(flag.Name))
+		fs.orderedActual = removeFromFlags(fs.orderedActual, flag)
+	}
+	fs.sortedFormal = nil
+	fs.sortedActual = nil
+}
+
+// replaceInConstraints replaces the flag with replacement in the flag groups
+// and conditional requirements. A nil replacement removes the flag, and drops
+// the flag groups left with less than two flags and the requirements left
+// without flags.
+func (fs *FlagSet) replaceInConstraints(flag, replacement *Flag) {
+	replace := func(flags []*Flag) []*Flag {
+		if replacement == nil {
+			return removeFromFlags(flags, flag)
+		}
+		for i, f := range flags {
+			if f == flag {
+				flags[i] = replacement
+			}
+		}
+		return flags
+	}
+
+	groups := fs.flagGroups[:0]
+	for _, group := range fs.flagGroups {
+		group.flags = replace(group.flags)
+		if len(group.flags) >= 2 {
+			groups = append(groups, group)
+		}
+	}
+	fs.flagGroups = groups
+
+	requirements := fs.requirements[:0]
+	for _, requirement := range fs.requirements {
+		requirement.flags = replace(requirement.flags)
+		if len(requirement.flags) > 0 {
+			requirements = append(requirements, requirement)
+		}
+	}
+	fs.requirements = requirements
+}
+
+// removeFromFlags returns the flags without flag.
+func removeFromFlags(flags []*Flag, flag *Flag) []*Flag {
+	result := make([]*Flag, 0, len(flags))
+	for _, f := range flags {
+		if f != flag {
+			result = append(result, f)
+		}
+	}
+	return result
 }
 
 // AddFlagSet adds one FlagSet to another. If a flag is already present in f
@@ -937,13 +1407,27 @@ func Var(value Value, name, usage string, opts ...Opt) *Flag {
 }
 
 // failf prints to standard error a formatted error and usage message and
-// returns the error.
+// returns the error. When collecting errors, nothing is printed until
+// the parsing is done.
 func (fs *FlagSet) failf(format string, a ...interface{}) error {
-	fs.usage()
 	err := fmt.Errorf(format, a...)
+	if !fs.CollectErrors {
+		fs.printError(err)
+	}
+	return err
+}
+
+// printError prints to standard error a usage message and the error.
+func (fs *FlagSet) printError(err error) {
+	fs.usage()
 	fmt.Fprintln(fs.Output())
+	if errs, ok := err.(ParseErrors); ok {
+		for _, err := range errs {
+			fmt.Fprintln(fs.Output(), err)
+		}
+		return
+	}
 	fmt.Fprintln(fs.Output(), err)
-	return err
 }
 
 // usage calls the Usage method for the flag set, or the usage function if
@@ -982,31 +1466,101 @@ func (fs *FlagSet) stripUnknownFlagValue(args []string) []string {
 	return nil
 }
 
+// lookupAbbreviation returns the flag whose name starts with the given prefix,
+// and whether the prefix matched the negative --no-<flag> form of a boolean flag.
+// It returns an error if the prefix matches more than one flag.
+func (fs *FlagSet) lookupAbbreviation(prefix string) (*Flag, bool, error) {
+	type candidate struct {
+		flag    *Flag
+		negated bool
+	}
+
+	normalPrefix := string(fs.normalizeFlagName(prefix))
+	negPrefix, matchNegative := "", strings.HasPrefix("no-", prefix)
+	if strings.HasPrefix(prefix, "no-") {
+		negPrefix, matchNegative = string(fs.normalizeFlagName(prefix[3:])), true
+	}
+
+	var candidates []candidate
+	seen := make(map[candidate]bool)
+	addCandidate := func(c candidate) {
+		if !seen[c] {
+			seen[c] = true
+			candidates = append(candidates, c)
+		}
+	}
+	for _, names := range []map[NormalizedName]*Flag{fs.formal, fs.aliases} {
+		for name, flag := range names {
+			if flag.ShorthandOnly {
+				continue
+			}
+			if strings.HasPrefix(string(name), normalPrefix) {
+				addCandidate(candidate{flag: flag})
+			}
+			if _, isBoolFlag := flag.Value.(BoolFlag); matchNegative && isBoolFlag && flag.AddNegative && strings.HasPrefix(string(name), negPrefix) {
+				addCandidate(candidate{flag: flag, negated: true})
+			}
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, false, nil
+	case 1:
+		return candidates[0].flag, candidates[0].negated, nil
+	}
+
+	names := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if c.negated {
+			names = append(names, "--no-"+c.flag.Name)
+		} else {
+			names = append(names, "--"+c.flag.Name)
+		}
+	}
+	sort.Strings(names)
+	return nil, false, AmbiguousFlagError{Flag: "--" + prefix, Candidates: names}
+}
+
 //nolint:funlen
 func (fs *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (outArgs []string, err error) {
 	outArgs = args
 	name := s[2:]
 	if len(name) == 0 || name[0] == '-' || name[0] == '=' {
-		err = fs.failf("bad flag syntax: %s", s)
+		err = fs.failf("%w", BadFlagSyntaxError{Token: s, Index: fs.argIndex})
 		return
 	}
 
 	hasNoPrefix := strings.HasPrefix(name, "no-")
 	split := strings.SplitN(name, "=", 2)
 	name = split[0]
-	flag, exists := fs.formal[fs.normalizeFlagName(name)]
+	flag := fs.lookup(fs.normalizeFlagName(name))
+	exists := flag != nil
 
 	if !exists && len(name) > 3 && hasNoPrefix {
-		bFlag, bExists := fs.formal[fs.normalizeFlagName(name[3:])]
-		if bExists && bFlag.AddNegative {
+		bFlag := fs.lookup(fs.normalizeFlagName(name[3:]))
+		if bFlag != nil && bFlag.AddNegative {
 			if _, isBoolFlag := bFlag.Value.(BoolFlag); isBoolFlag {
 				flag = bFlag
-				exists = bExists
+				exists = true
 				name = name[3:]
 			}
 		}
 	}
 
+	if !exists && fs.AllowAbbreviations {
+		var negated bool
+		flag, negated, err = fs.lookupAbbreviation(name)
+		if err != nil {
+			err = fs.failf("%w", err)
+			return
+		}
+		if flag != nil {
+			exists = true
+			hasNoPrefix = negated
+		}
+	}
+
 	if !exists || (flag != nil && flag.ShorthandOnly) {
 		switch {
 		case !exists && name == "help" && !fs.DisableBuiltinHelp:
@@ -1023,47 +1577,129 @@ func (fs *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (outArgs
 			outArgs = fs.stripUnknownFlagValue(outArgs)
 			return
 		default:
-			err = fs.failf(NewUnknownFlagError(name).Error())
+			err = fs.failf("%w", UnknownFlagError{
+				Token:       s,
+				Index:       fs.argIndex,
+				Suggestions: fs.suggestFlags(name),
+				name:        name,
+			})
 			return
 		}
 	}
 
 	_, flagIsBool := flag.Value.(BoolFlag)
 	_, isOptional := flag.Value.(OptionalValue)
-	nextArgIsFlagValue := len(outArgs) > 0 && len(outArgs[0]) > 0 && outArgs[0][0] != '-'
+	nextArgIsFlagValue := len(outArgs) > 0 && fs.isFlagValue(flag, outArgs[0])
 
 	var value string
+	form := FormLong
+	if hasNoPrefix && flagIsBool {
+		form |= FormNegated
+	}
 	switch {
 	case len(split) == 2: // '--flag=arg'
 		value = split[1]
+		form |= FormInlineValue
 		if hasNoPrefix && flagIsBool {
-			err = fs.failf("flag cannot have a value: %s", s)
+			err = fs.failf("%w", FlagValueNotAllowedError{Flag: "--no-" + flag.Name, Token: s, Index: fs.argIndex})
 			return
 		}
 	case flagIsBool: // '--[no-]flag' (arg was optional)
 		value = fmt.Sprintf("%t", !hasNoPrefix)
+	case flag.NoOptDefVal != "": // '--flag' (arg was optional)
+		value = flag.NoOptDefVal
 	case isOptional: // '--flag' (arg was optional)
 		value = ""
 	case nextArgIsFlagValue && (!flagIsBool || (flagIsBool && isBool(outArgs[0]))): // '--flag arg'
 		value = outArgs[0]
 		outArgs = outArgs[1:]
+		form |= FormSeparateValue
 	default: // '--flag' (arg was required)
-		err = fs.failf("flag needs an argument: %s", s)
+		err = fs.ignoreOrFail(
+			FlagNeedsArgumentError{Flag: "--" + flag.Name, Token: s, Index: fs.argIndex},
+			fs.ParseErrorsAllowList.MissingArguments,
+		)
 		return
 	}
 
-	err = fn(flag, value)
-	if err != nil {
-		err = fs.failf(err.Error())
-	}
+	fs.addOccurrence(flag, name, form, value)
+	err = fs.callParseFunc(fn, flag, value)
 	return
 }
 
+// callParseFunc calls fn with the flag and value, and fails with its error
+// unless it is an invalid value which is allowed. Invalid value errors get
+// the argument being parsed and its position.
+func (fs *FlagSet) callParseFunc(fn parseFunc, flag *Flag, value string) error {
+	err := fn(flag, value)
+	if err == nil {
+		return nil
+	}
+
+	if invalidErr, ok := err.(InvalidArgumentError); ok {
+		invalidErr.Token, invalidErr.Index = fs.argToken, fs.argIndex
+		err = invalidErr
+	}
+
+	var invalidErr InvalidArgumentError
+	return fs.ignoreOrFail(err, errors.As(err, &invalidErr) && fs.allowsInvalidValue(flag))
+}
+
 func isBool(v string) bool {
 	_, err := strconv.ParseBool(v)
 	return err == nil
 }
 
+// isFlagValue returns whether arg, passed after the flag as a separate
+// argument, is the value of the flag. Arguments starting with a dash are
+// only values if the flag allows it, or if they are negative numbers passed
+// to a numeric flag and do not clash with a shorthand.
+func (fs *FlagSet) isFlagValue(flag *Flag, arg string) bool {
+	switch {
+	case len(arg) == 0:
+		return false
+	case arg[0] != '-':
+		return true
+	case arg == "--":
+		return false
+	case flag.AllowDashValue:
+		return true
+	case isNumericFlag(flag) && isNegativeNumber(arg):
+		_, isShorthand := fs.shorthands[rune(arg[1])]
+		return !isShorthand
+	}
+	return false
+}
+
+// isNumericFlag returns whether the flag holds a number, or a slice of numbers.
+func isNumericFlag(flag *Flag) bool {
+	v, ok := flag.Value.(Typed)
+	if !ok {
+		return false
+	}
+
+	switch strings.TrimSuffix(v.Type(), "Slice") {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "complex128", "duration":
+		return true
+	}
+	return false
+}
+
+// isNegativeNumber returns whether arg looks like a negative number, e.g. -5 or -.5.
+func isNegativeNumber(arg string) bool {
+	if len(arg) < 2 || arg[0] != '-' {
+		return false
+	}
+
+	digits := arg[1:]
+	if digits[0] == '.' {
+		digits = digits[1:]
+	}
+	return len(digits) > 0 && digits[0] >= '0' && digits[0] <= '9'
+}
+
 //nolint:funlen
 func (fs *FlagSet) parseSingleShortArg(shorthands string, args []string, fn parseFunc) (outShorts string, outArgs []string, err error) {
 	outArgs = args
@@ -1093,8 +1729,15 @@ func (fs *FlagSet) parseSingleShortArg(shorthands string, args []string, fn pars
 		default:
 			// fallback to a normal flag look up without any shorthand opts
 			flag = fs.Lookup(string(char))
-			if flag == nil || (flag.Shorthand > 0 && flag.Shorthand != char) {
-				err = fs.failf("unknown shorthand flag: %q in -%s", char, shorthands)
+			if flag == nil || (len(flag.allShorthands()) > 0 && !flag.hasShorthand(char)) {
+				err = fs.failf("%w", UnknownShorthandFlagError{
+					Flag:        "-" + string(char),
+					Token:       fs.argToken,
+					Index:       fs.argIndex,
+					Suggestions: fs.suggestFlags(string(char)),
+					shorthand:   char,
+					shorthands:  shorthands,
+				})
 				return
 			}
 		}
@@ -1102,7 +1745,7 @@ func (fs *FlagSet) parseSingleShortArg(shorthands string, args []string, fn pars
 
 	_, flagIsBool := flag.Value.(BoolFlag)
 	_, isOptional := flag.Value.(OptionalValue)
-	nextArgIsFlagValue := len(outArgs) > 0 && len(outArgs[0]) > 0 && outArgs[0][0] != '-'
+	nextArgIsFlagValue := len(outArgs) > 0 && fs.isFlagValue(flag, outArgs[0])
 
 	nextShortArgIsFlagValue := len(shorthands) > 1
 	if len(shorthands) > 1 {
@@ -1111,36 +1754,47 @@ func (fs *FlagSet) parseSingleShortArg(shorthands string, args []string, fn pars
 	}
 
 	var value string
+	form := FormShorthand
 	switch {
 	case len(shorthands) > 2 && shorthands[1] == '=':
 		// '-f=arg'
 		value = shorthands[2:]
 		outShorts = ""
+		form |= FormInlineValue
+	case flag.NoOptDefVal != "" && !flagIsBool:
+		// '-f' (arg was optional)
+		value = flag.NoOptDefVal
 	case nextShortArgIsFlagValue && (!flagIsBool || (flagIsBool && isBool(shorthands[1:]))):
 		// '-farg'
 		value = shorthands[1:]
 		outShorts = ""
+		form |= FormInlineValue
 	case nextArgIsFlagValue && (!flagIsBool || (flagIsBool && isBool(outArgs[0]))):
 		// '-f arg'
 		value = args[0]
 		outArgs = args[1:]
+		form |= FormSeparateValue
 	case flagIsBool, isOptional:
 		// '-f' (arg was optional)
 		value = ""
 	default:
 		// '-f' (arg was required)
-		err = fs.failf("flag needs an argument: %q in -%s", char, shorthands)
+		err = fs.ignoreOrFail(FlagNeedsArgumentError{
+			Flag:       "-" + string(char),
+			Token:      fs.argToken,
+			Index:      fs.argIndex,
+			shorthand:  char,
+			shorthands: shorthands,
+		}, fs.ParseErrorsAllowList.MissingArguments)
 		return
 	}
 
-	if flag.ShorthandDeprecated != "" {
-		fmt.Fprintf(fs.Output(), "Flag shorthand -%c has been deprecated, %s\n", flag.Shorthand, flag.ShorthandDeprecated)
+	if msg := flag.shorthandDeprecation(char); msg != "" {
+		fs.deprecationNotice(DeprecatedFlagError{Flag: "-" + string(char), Message: msg, shorthand: true})
 	}
 
-	err = fn(flag, value)
-	if err != nil {
-		err = fs.failf(err.Error())
-	}
+	fs.addOccurrence(flag, string(char), form, value)
+	err = fs.callParseFunc(fn, flag, value)
 	return
 }
 
@@ -1160,35 +1814,141 @@ func (fs *FlagSet) parseShortArg(s string, args []string, fn parseFunc) (outArgs
 }
 
 func (fs *FlagSet) parseArgs(args []string, fn parseFunc) (err error) {
+	var errs ParseErrors
+	// indexes holds the index in the original arguments of each of the args.
+	// Arguments read from a response file get the index of the response file.
+	indexes := make([]int, len(args))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
 	for len(args) > 0 {
 		s := args[0]
 		args = args[1:]
-		if len(s) == 0 || s[0] != '-' || len(s) == 1 {
-			if !fs.interspersed {
-				fs.args = append(fs.args, s)
-				fs.args = append(fs.args, args...)
-				return nil
+		fs.argToken, fs.argIndex = s, indexes[0]
+		indexes = indexes[1:]
+		if fs.EnableResponseFiles && isResponseFile(s) {
+			args, indexes, err = fs.expandResponseFileArg(s, args, indexes)
+			if err != nil {
+				if !fs.CollectErrors {
+					return err
+				}
+				return fs.collectedErrors(append(errs, err))
 			}
-			fs.args = append(fs.args, s)
 			continue
 		}
+		if fs.EnableResponseFiles && strings.HasPrefix(s, "@@") {
+			s = s[1:]
+		}
 
-		if s[1] == '-' {
-			if len(s) == 2 && s == "--" { // "--" terminates the flags
-				fs.argsLenAtDash = len(fs.args)
-				fs.args = append(fs.args, args...)
+		if len(s) == 0 || s[0] != '-' || len(s) == 1 {
+			if fs.addPositional(s, args, indexes) {
 				break
 			}
-			args, err = fs.parseLongArg(s, args, fn)
-		} else {
-			args, err = fs.parseShortArg(s, args, fn)
+			continue
 		}
+		if s == "--" {
+			fs.addTerminator(args, indexes)
+			break
+		}
+
+		args, err = fs.parseFlagArg(s, args, fn)
 		if err != nil {
-			return
+			if !fs.CollectErrors || err == ErrHelp {
+				return
+			}
+			errs = append(errs, err)
 		}
+		indexes = indexes[len(indexes)-len(args):]
+	}
+
+	if fs.tokenizing {
+		return fs.collectedErrors(errs)
+	}
+	return fs.finishParse(errs)
+}
+
+// addPositional adds the non-flag argument s. When flags and arguments may not
+// be interspersed the remaining arguments are added as well, and true is
+// returned to stop parsing.
+func (fs *FlagSet) addPositional(s string, args []string, indexes []int) bool {
+	fs.addPositionalTokens([]string{fs.argToken}, []int{fs.argIndex})
+	fs.args = append(fs.args, s)
+	if fs.interspersed {
+		return false
 	}
 
-	return fs.Validate()
+	fs.args = append(fs.args, args...)
+	fs.addPositionalTokens(args, indexes)
+	return true
+}
+
+// addTerminator adds the arguments after a "--", which terminates the flags.
+func (fs *FlagSet) addTerminator(args []string, indexes []int) {
+	fs.argsLenAtDash = len(fs.args)
+	fs.args = append(fs.args, args...)
+	fs.addTerminatorToken()
+	fs.addPositionalTokens(args, indexes)
+}
+
+// parseFlagArg parses the long or shorthand flags in s, and returns the
+// remaining arguments. The tokens of the flags are added when tokenizing and
+// parsing goes on.
+func (fs *FlagSet) parseFlagArg(s string, args []string, fn parseFunc) (outArgs []string, err error) {
+	unknownFlags := len(fs.unknownFlags)
+	if s[1] == '-' {
+		outArgs, err = fs.parseLongArg(s, args, fn)
+	} else {
+		outArgs, err = fs.parseShortArg(s, args, fn)
+	}
+	if err == nil || (fs.CollectErrors && err != ErrHelp) {
+		fs.addFlagTokens(args[:len(args)-len(outArgs)], unknownFlags)
+	}
+	return outArgs, err
+}
+
+// finishParse sets the flags from the providers and validates the flags. When
+// collecting errors, their errors are added to the errors of the arguments.
+// Like without collecting errors, only the errors of the arguments and the
+// providers cause the usage and errors to be printed.
+func (fs *FlagSet) finishParse(errs ParseErrors) error {
+	if err := fs.parseProviders(); err != nil {
+		if !fs.CollectErrors {
+			fs.printError(err)
+			return err
+		}
+		errs = append(errs, err)
+	}
+	argErrs := len(errs)
+
+	if err := fs.Validate(); err != nil {
+		if !fs.CollectErrors {
+			return err
+		}
+		if validationErrs, ok := err.(ValidationErrors); ok {
+			errs = append(errs, validationErrs...)
+		} else {
+			errs = append(errs, err)
+		}
+	}
+
+	if argErrs > 0 {
+		return fs.collectedErrors(errs)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// collectedErrors prints and returns the collected errors, or returns nil if
+// there are none.
+func (fs *FlagSet) collectedErrors(errs ParseErrors) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	fs.printError(errs)
+	return errs
 }
 
 var exitFn = func(code int) {
@@ -1204,14 +1964,17 @@ func (fs *FlagSet) parseAll(arguments []string, fn parseFunc) error {
 		}
 	}
 	fs.parsed = true
+	if fs.FreezeAfterParse {
+		defer fs.Freeze()
+	}
 
+	var err error
 	if len(arguments) == 0 {
-		return fs.Validate()
+		err = fs.finishParse(nil)
+	} else {
+		fs.args = make([]string, 0, len(arguments))
+		err = fs.parseArgs(arguments, fn)
 	}
-
-	fs.args = make([]string, 0, len(arguments))
-
-	err := fs.parseArgs(arguments, fn)
 	if err != nil {
 		switch fs.errorHandling {
 		case ContinueOnError:
@@ -1234,7 +1997,7 @@ func (fs *FlagSet) parseAll(arguments []string, fn parseFunc) error {
 // The return value will be ErrHelp if -help was set but not defined.
 func (fs *FlagSet) Parse(arguments []string) error {
 	set := func(flag *Flag, value string) error {
-		return fs.Set(flag.Name, value)
+		return fs.set(flag.Name, value, SourceCommandLine)
 	}
 	return fs.parseAll(arguments, set)
 }
@@ -1250,6 +2013,46 @@ func (fs *FlagSet) ParseAll(arguments []string, fn func(flag *Flag, value string
 	return fs.parseAll(arguments, fn)
 }
 
+// Reset restores the default value of every flag and clears the state of
+// parsing, so that the FlagSet can be parsed again as if it was new. It
+// returns an error if a value not implementing Resetter could not be set to
+// the default value of its flag. The other flags are reset regardless. A
+// frozen FlagSet is not reset, and a FrozenFlagSetError is returned.
+func (fs *FlagSet) Reset() error {
+	defer fs.lock()()
+	if fs.frozen {
+		return FrozenFlagSetError{FlagSet: fs.name}
+	}
+
+	var err error
+	for _, flag := range fs.orderedFormal {
+		if resetter, ok := flag.Value.(Resetter); ok {
+			resetter.Reset()
+		} else if setErr := flag.Value.Set(flag.DefValue); setErr != nil && err == nil {
+			err = fmt.Errorf("unable to reset flag %q: %w", flag.Name, setErr)
+		}
+		flag.Changed = false
+		flag.source = ""
+		flag.occurrences = nil
+	}
+
+	fs.parsed = false
+	fs.actual = nil
+	fs.orderedActual = nil
+	fs.sortedActual = nil
+	fs.args = nil
+	fs.argsLenAtDash = -1
+	fs.unknownFlags = nil
+	fs.ignoredErrors = nil
+
+	return err
+}
+
+// Reset restores the default value of every command-line flag and clears the state of parsing.
+func Reset() error {
+	return CommandLine.Reset()
+}
+
 // Parsed reports whether f.Parse has been called.
 func (fs *FlagSet) Parsed() bool {
 	return fs.parsed
@@ -1310,20 +2113,39 @@ func (fs *FlagSet) Init(name string, errorHandling ErrorHandling) {
 	fs.argsLenAtDash = -1
 }
 
-// Validate ensures all flag values are valid.
+// Validate ensures all flag values are valid. It checks the required flags,
+// the flag groups, the conditional requirements and finally runs the
+// validators added with AddValidator. If more than one check fails, the
+// errors are returned as ValidationErrors.
 func (fs *FlagSet) Validate() error {
+	var errs ValidationErrors
 	if !fs.ParseErrorsAllowList.RequiredFlags {
 		var missingFlagsErr MissingFlagsError
 		fs.VisitAll(func(f *Flag) {
-			if f.Required && !f.Changed {
+			if f.Required && !f.isSet() {
 				missingFlagsErr.AddMissingFlag(f)
 			}
 		})
 
 		if len(missingFlagsErr) > 0 {
-			return missingFlagsErr
+			errs = append(errs, missingFlagsErr)
 		}
 	}
 
-	return nil
+	errs = append(errs, fs.validateFlagGroups()...)
+	errs = append(errs, fs.validateConditionalRequirements()...)
+	for _, validator := range fs.validators {
+		if err := validator(fs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errs
+	}
 }
@@ -0,0 +1,144 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+type flagGroupKind int
+
+const (
+	mutuallyExclusive flagGroupKind = iota
+	requiredTogether
+	oneRequired
+)
+
+// flagGroup is a constraint on a group of flags, checked by Validate.
+type flagGroup struct {
+	kind  flagGroupKind
+	flags []*Flag
+}
+
+// MarkMutuallyExclusive marks the named flags as mutually exclusive: at most
+// one of them may be set. It panics if one of the flags does not exist.
+func (fs *FlagSet) MarkMutuallyExclusive(names ...string) {
+	fs.addFlagGroup(mutuallyExclusive, names)
+}
+
+// MarkMutuallyExclusive marks the named command-line flags as mutually exclusive.
+func MarkMutuallyExclusive(names ...string) {
+	CommandLine.MarkMutuallyExclusive(names...)
+}
+
+// MarkRequiredTogether marks the named flags as required together: if one of
+// them is set, all of them must be set. It panics if one of the flags does not
+// exist.
+func (fs *FlagSet) MarkRequiredTogether(names ...string) {
+	fs.addFlagGroup(requiredTogether, names)
+}
+
+// MarkRequiredTogether marks the named command-line flags as required together.
+func MarkRequiredTogether(names ...string) {
+	CommandLine.MarkRequiredTogether(names...)
+}
+
+// MarkOneRequired marks the named flags as a group of which at least one must
+// be set. It panics if one of the flags does not exist.
+func (fs *FlagSet) MarkOneRequired(names ...string) {
+	fs.addFlagGroup(oneRequired, names)
+}
+
+// MarkOneRequired marks the named command-line flags as a group of which at least one must be set.
+func MarkOneRequired(names ...string) {
+	CommandLine.MarkOneRequired(names...)
+}
+
+func (fs *FlagSet) addFlagGroup(kind flagGroupKind, names []string) {
+	fs.checkNotFrozen("add a flag group")
+	if len(names) < 2 {
+		msg := fmt.Sprintf("a flag group in %q flagset needs at least two flags, got %q", fs.name, names)
+		fmt.Fprintln(fs.Output(), msg)
+		panic(msg)
+	}
+
+	group := flagGroup{kind: kind, flags: make([]*Flag, 0, len(names))}
+	for _, name := range names {
+		flag := fs.Lookup(name)
+		if flag == nil {
+			msg := fmt.Sprintf("unable to add %q to a flag group in %q flagset: flag does not exist", name, fs.name)
+			fmt.Fprintln(fs.Output(), msg)
+			panic(msg)
+		}
+		group.flags = append(group.flags, flag)
+	}
+
+	fs.flagGroups = append(fs.flagGroups, group)
+}
+
+// validateFlagGroups returns an error for every flag group whose constraint
+// is not met.
+func (fs *FlagSet) validateFlagGroups() []error {
+	if fs.ParseErrorsAllowList.FlagGroups {
+		return nil
+	}
+
+	var errs []error
+	for _, group := range fs.flagGroups {
+		var set, unset []string
+		for _, flag := range group.flags {
+			if flag.isSet() {
+				set = append(set, getFlagWithDashes(flag.Name))
+			} else {
+				unset = append(unset, getFlagWithDashes(flag.Name))
+			}
+		}
+
+		switch {
+		case group.kind == mutuallyExclusive && len(set) > 1:
+			errs = append(errs, MutuallyExclusiveFlagsError{Flags: group.names(), Set: set})
+		case fs.ParseErrorsAllowList.RequiredFlags:
+			continue
+		case group.kind == requiredTogether && len(set) > 0 && len(unset) > 0:
+			errs = append(errs, RequiredTogetherFlagsError{Flags: group.names(), Missing: unset})
+		case group.kind == oneRequired && len(set) == 0:
+			errs = append(errs, OneRequiredFlagsError{Flags: group.names()})
+		}
+	}
+
+	return errs
+}
+
+// names returns the names of the flags in the group, with dashes.
+func (g flagGroup) names() []string {
+	names := make([]string, 0, len(g.flags))
+	for _, flag := range g.flags {
+		names = append(names, getFlagWithDashes(flag.Name))
+	}
+	return names
+}
+
+// FlagGroupUsages returns a string describing the flag groups of the FlagSet,
+// one group per line, for use in help messages.
+func (fs *FlagSet) FlagGroupUsages() string {
+	buf := new(strings.Builder)
+	for _, group := range fs.flagGroups {
+		names := strings.Join(group.names(), ", ")
+		switch group.kind {
+		case mutuallyExclusive:
+			fmt.Fprintf(buf, "  %s are mutually exclusive\n", names)
+		case requiredTogether:
+			fmt.Fprintf(buf, "  %s must be used together\n", names)
+		case oneRequired:
+			fmt.Fprintf(buf, "  one of %s is required\n", names)
+		}
+	}
+	return buf.String()
+}
+
+// FlagGroupUsages returns a string describing the flag groups of the command-line flags.
+func FlagGroupUsages() string {
+	return CommandLine.FlagGroupUsages()
+}
@@ -0,0 +1,162 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestFlagGroups(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		allowList   zflag.ParseErrorsAllowList
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			args: []string{"--json", "--user=a", "--password=b", "--file=c"},
+		},
+		{
+			name:        "mutually exclusive",
+			args:        []string{"--json", "--yaml", "--file=c"},
+			expectedErr: `flag(s) "--json", "--yaml" are mutually exclusive, but "--json", "--yaml" were set`,
+		},
+		{
+			name:        "required together",
+			args:        []string{"--user=a", "--file=c"},
+			expectedErr: `flag(s) "--user", "--password" must be set together, but "--password" not set`,
+		},
+		{
+			name:        "one required",
+			args:        []string{"--json"},
+			expectedErr: `one of the flag(s) "--file", "--url" must be set`,
+		},
+		{
+			name:      "flag groups allowed",
+			args:      []string{"--json", "--yaml", "--user=a"},
+			allowList: zflag.ParseErrorsAllowList{FlagGroups: true},
+		},
+		{
+			name:      "required flags allowed",
+			args:      []string{"--user=a"},
+			allowList: zflag.ParseErrorsAllowList{RequiredFlags: true},
+		},
+		{
+			name:        "required flags allowed and mutually exclusive",
+			args:        []string{"--json", "--yaml"},
+			allowList:   zflag.ParseErrorsAllowList{RequiredFlags: true},
+			expectedErr: `flag(s) "--json", "--yaml" are mutually exclusive, but "--json", "--yaml" were set`,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.ParseErrorsAllowList = test.allowList
+			f.Bool("json", false, "usage")
+			f.Bool("yaml", false, "usage")
+			f.String("user", "", "usage")
+			f.String("password", "", "usage")
+			f.String("file", "", "usage")
+			f.String("url", "", "usage")
+			f.MarkMutuallyExclusive("json", "yaml")
+			f.MarkRequiredTogether("user", "password")
+			f.MarkOneRequired("file", "url")
+
+			err := f.Parse(test.args)
+			if test.expectedErr == "" {
+				assertNoErr(t, err)
+				return
+			}
+			assertErrMsg(t, test.expectedErr, err)
+		})
+	}
+}
+
+func TestFlagGroupsErrorTypes(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("json", false, "usage")
+	f.Bool("yaml", false, "usage")
+	f.MarkMutuallyExclusive("json", "yaml")
+	err := f.Parse([]string{"--json", "--yaml"})
+	var exclusiveErr zflag.MutuallyExclusiveFlagsError
+	if !errors.As(err, &exclusiveErr) {
+		t.Fatalf("expected a MutuallyExclusiveFlagsError, got %v", err)
+	}
+	assertDeepEqual(t, []string{"--json", "--yaml"}, exclusiveErr.Set)
+
+	f = zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("user", "", "usage")
+	f.String("password", "", "usage")
+	f.MarkRequiredTogether("user", "password")
+	err = f.Parse([]string{"--password=b"})
+	var togetherErr zflag.RequiredTogetherFlagsError
+	if !errors.As(err, &togetherErr) {
+		t.Fatalf("expected a RequiredTogetherFlagsError, got %v", err)
+	}
+	assertDeepEqual(t, []string{"--user"}, togetherErr.Missing)
+
+	f = zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("file", "", "usage")
+	f.String("url", "", "usage")
+	f.MarkOneRequired("file", "url")
+	err = f.Parse([]string{})
+	var oneRequiredErr zflag.OneRequiredFlagsError
+	if !errors.As(err, &oneRequiredErr) {
+		t.Fatalf("expected a OneRequiredFlagsError, got %v", err)
+	}
+	assertDeepEqual(t, []string{"--file", "--url"}, oneRequiredErr.Flags)
+}
+
+func TestFlagGroupsSources(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("user", "", "usage")
+	f.String("password", "", "usage")
+	f.MarkRequiredTogether("user", "password")
+	assertNoErr(t, f.LoadConfig(strings.NewReader("user: b"), zflag.ConfigYAML))
+	err := f.Parse([]string{})
+	assertErrMsg(t, `flag(s) "--user", "--password" must be set together, but "--password" not set`, err)
+}
+
+func TestFlagGroupsUnknownFlag(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("json", false, "usage")
+	defer assertPanic(t)()
+	f.MarkMutuallyExclusive("json", "unknown")
+}
+
+func TestFlagGroupUsages(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Bool("json", false, "usage")
+	f.Bool("yaml", false, "usage")
+	f.String("user", "", "usage")
+	f.String("password", "", "usage")
+	f.String("file", "", "usage")
+	f.String("url", "", "usage")
+	f.MarkMutuallyExclusive("json", "yaml")
+	f.MarkRequiredTogether("user", "password")
+	f.MarkOneRequired("file", "url")
+	expected := []string{
+		`  --json, --yaml are mutually exclusive`,
+		`  --user, --password must be used together`,
+		`  one of --file, --url is required`,
+	}
+	assertEqual(t, strings.Join(expected, "\n")+"\n", f.FlagGroupUsages())
+}
@@ -34,6 +34,23 @@ func OptShorthand(shorthand rune) Opt {
 	}
 }
 
+// OptShorthands one-letter abbreviated flags. The first rune is used as the
+// Shorthand if it is not set yet, the others are added to Shorthands.
+func OptShorthands(shorthands ...rune) Opt {
+	return func(f *Flag) error {
+		if len(shorthands) == 0 {
+			return fmt.Errorf("shorthands for flag %q must be set", f.Name)
+		}
+
+		if f.Shorthand == 0 {
+			f.Shorthand = shorthands[0]
+			shorthands = shorthands[1:]
+		}
+		f.Shorthands = append(f.Shorthands, shorthands...)
+		return nil
+	}
+}
+
 // OptShorthandStr one-letter abbreviated flag
 func OptShorthandStr(shorthand string) Opt {
 	r, err := shorthandStrToRune(shorthand)
@@ -44,6 +61,28 @@ func OptShorthandStr(shorthand string) Opt {
 	return OptShorthand(r)
 }
 
+// OptAlias adds additional long names for the flag. Aliases are resolved
+// everywhere the name of the flag is.
+func OptAlias(names ...string) Opt {
+	return func(f *Flag) error {
+		for _, name := range names {
+			if name == "" {
+				return fmt.Errorf("alias for flag %q must not be empty", f.Name)
+			}
+		}
+		f.Aliases = append(f.Aliases, names...)
+		return nil
+	}
+}
+
+// OptShowAliases lists the aliases of the flag in the help message.
+func OptShowAliases() Opt {
+	return func(f *Flag) error {
+		f.ShowAliases = true
+		return nil
+	}
+}
+
 // OptShorthandOnly If the user set only the shorthand
 func OptShorthandOnly() Opt {
 	return func(f *Flag) error {
@@ -114,6 +153,14 @@ func OptHidden() Opt {
 	}
 }
 
+// OptMutable allows the flag to be set after the FlagSet is frozen, see FlagSet.Freeze.
+func OptMutable() Opt {
+	return func(f *Flag) error {
+		f.Mutable = true
+		return nil
+	}
+}
+
 // OptRequired ensures that a flag must be changed
 func OptRequired() Opt {
 	return func(f *Flag) error {
@@ -134,6 +181,69 @@ func OptShorthandDeprecated(msg string) Opt {
 	}
 }
 
+// OptDeprecatedShorthand If one of the shorthands of this flag is deprecated, this string is the new or now thing to use
+func OptDeprecatedShorthand(shorthand rune, msg string) Opt {
+	return func(f *Flag) error {
+		if msg == "" {
+			return fmt.Errorf("shorthand deprecated message for flag %q must be set", f.Name)
+		}
+
+		if f.ShorthandsDeprecated == nil {
+			f.ShorthandsDeprecated = make(map[rune]string)
+		}
+		f.ShorthandsDeprecated[shorthand] = msg
+		return nil
+	}
+}
+
+// OptNoOptDefault sets the value used when the flag is passed without a value,
+// e.g. --color instead of --color=always. The value of the flag can then only
+// be passed using --flag=value or -f=value.
+func OptNoOptDefault(value string) Opt {
+	return func(f *Flag) error {
+		if value == "" {
+			return fmt.Errorf("no option default for flag %q must be set", f.Name)
+		}
+
+		f.NoOptDefVal = value
+		return nil
+	}
+}
+
+// OptChoices restricts the values of the flag to the given choices. For slice
+// flags every element must be one of the choices. The choices are listed in
+// the help message.
+func OptChoices(choices ...string) Opt {
+	return func(f *Flag) error {
+		if len(choices) == 0 {
+			return fmt.Errorf("choices for flag %q must be set", f.Name)
+		}
+
+		f.Choices = append(f.Choices, choices...)
+		return nil
+	}
+}
+
+// OptValidate adds a function which validates the value of the flag after it
+// is set. The function is passed the value returned by Get, or the string
+// representation if the Value does not implement Getter. When it returns an
+// error, the flag keeps its previous value.
+func OptValidate(validator func(value interface{}) error) Opt {
+	return func(f *Flag) error {
+		f.Validators = append(f.Validators, validator)
+		return nil
+	}
+}
+
+// OptAllowDashValue allows the value of the flag to start with a dash when
+// passed as a separate argument, e.g. --file - or --pattern -foo.
+func OptAllowDashValue() Opt {
+	return func(f *Flag) error {
+		f.AllowDashValue = true
+		return nil
+	}
+}
+
 // OptGroup flag group
 func OptGroup(group string) Opt {
 	return func(f *Flag) error {
@@ -142,6 +252,19 @@ func OptGroup(group string) Opt {
 	}
 }
 
+// OptEnv reads the flag value from the named environment variable when the
+// flag is not set on the command line
+func OptEnv(name string) Opt {
+	return func(f *Flag) error {
+		if name == "" {
+			return fmt.Errorf("environment variable for flag %q must be set", f.Name)
+		}
+
+		f.EnvVar = name
+		return nil
+	}
+}
+
 // OptAnnotation Use it to annotate this specific flag for your application
 func OptAnnotation(key string, value []string) Opt {
 	return func(f *Flag) error {
@@ -4,6 +4,8 @@
 package zflag_test
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -225,6 +227,167 @@ func TestRemoveFlag(t *testing.T) {
 	})
 }
 
+func TestRemoveFlagComplete(t *testing.T) {
+	f := zflag.NewFlagSet("removing-flags", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SortFlags = false
+	f.String("string1", "a", "usage", zflag.OptShorthand('s'), zflag.OptAlias("str"))
+	f.String("string2", "b", "usage")
+	f.String("string3", "c", "usage")
+	f.MarkMutuallyExclusive("string1", "string2")
+	f.MarkRequiredTogether("string1", "string2", "string3")
+	assertNoErr(t, f.Set("string1", "x"))
+
+	f.RemoveFlag("str")
+	var names []string
+	f.VisitAll(func(flag *zflag.Flag) {
+		names = append(names, flag.Name)
+	})
+	assertDeepEqual(t, []string{"string2", "string3"}, names)
+	assertEqual(t, (*zflag.Flag)(nil), f.ShorthandLookup('s'))
+	assertEqual(t, 0, f.NFlag())
+	assertEqual(t, false, f.Changed("string1"))
+	assertEqual(t, "  --string2, --string3 must be used together\n", f.FlagGroupUsages())
+	assertErr(t, f.Parse([]string{"-s", "x"}))
+
+	f.String("string1", "d", "usage", zflag.OptShorthand('s'))
+	assertNoErr(t, f.Set("string1", "y"))
+}
+
+func TestReplaceFlag(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SortFlags = false
+	f.String("first", "", "usage")
+	f.String("name", "", "usage", zflag.OptShorthand('n'))
+	f.String("last", "", "usage")
+	f.MarkMutuallyExclusive("name", "last")
+
+	f.ReplaceFlag("name", &zflag.Flag{
+		Name:      "user",
+		Shorthand: 'u',
+		Usage:     "the user",
+		Value:     new(customValue),
+	})
+
+	var names []string
+	f.VisitAll(func(flag *zflag.Flag) {
+		names = append(names, flag.Name)
+	})
+	assertDeepEqual(t, []string{"first", "user", "last"}, names)
+	assertEqual(t, (*zflag.Flag)(nil), f.Lookup("name"))
+	assertEqual(t, (*zflag.Flag)(nil), f.ShorthandLookup('n'))
+	assertEqual(t, "user", f.ShorthandLookup('u').Name)
+	assertErrMsg(t, `flag(s) "--user", "--last" are mutually exclusive, but "--user", "--last" were set`,
+		f.Parse([]string{"-u", "1", "--last=a"}))
+}
+
+func TestReplaceFlagUnknown(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	defer assertPanic(t)()
+	f.ReplaceFlag("unknown", &zflag.Flag{Name: "user", Value: new(customValue)})
+}
+
+func TestReplaceFlagRedefined(t *testing.T) {
+	tests := []struct {
+		name        string
+		flag        *zflag.Flag
+		expectedErr string
+	}{
+		{
+			name:        "name",
+			flag:        &zflag.Flag{Name: "other", Value: new(customValue)},
+			expectedErr: "test flag redefined: other",
+		},
+		{
+			name:        "alias",
+			flag:        &zflag.Flag{Name: "user", Aliases: []string{"alias"}, Value: new(customValue)},
+			expectedErr: "test flag redefined: alias",
+		},
+		{
+			name:        "shorthand",
+			flag:        &zflag.Flag{Name: "user", Shorthand: 'o', Value: new(customValue)},
+			expectedErr: `unable to redefine 'o' shorthand in "test" flagset: it's already used for "other" flag`,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.String("name", "", "usage", zflag.OptShorthand('n'), zflag.OptAlias("nick"))
+			f.String("other", "", "usage", zflag.OptShorthand('o'), zflag.OptAlias("alias"))
+
+			func() {
+				defer func() {
+					assertEqual(t, test.expectedErr, recover())
+				}()
+				f.ReplaceFlag("name", test.flag)
+			}()
+
+			flag := f.Lookup("name")
+			if flag == nil {
+				t.Fatal("expected flag \"name\" to be kept")
+			}
+			assertEqual(t, flag, f.Lookup("nick"))
+			assertEqual(t, flag, f.ShorthandLookup('n'))
+			assertEqual(t, (*zflag.Flag)(nil), f.Lookup("user"))
+		})
+	}
+}
+
+func TestReplaceFlagReusesNames(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("name", "", "usage", zflag.OptShorthand('n'), zflag.OptAlias("nick"))
+
+	flag := &zflag.Flag{Name: "name", Shorthand: 'n', Aliases: []string{"nick"}, Value: new(customValue)}
+	f.ReplaceFlag("name", flag)
+	assertEqual(t, flag, f.Lookup("name"))
+	assertEqual(t, flag, f.Lookup("nick"))
+	assertEqual(t, flag, f.ShorthandLookup('n'))
+}
+
+func TestRenameFlag(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetEnvPrefix("app")
+	name := f.String("name", "default", "usage", zflag.OptShorthand('n'), zflag.OptAlias("nick"))
+	f.String("other", "", "usage")
+	f.MarkRequiredTogether("name", "other")
+	assertNoErr(t, f.Set("name", "a"))
+
+	f.RenameFlag("name", "user")
+	assertEqual(t, (*zflag.Flag)(nil), f.Lookup("name"))
+	flag := f.Lookup("user")
+	assertEqual(t, flag, f.Lookup("nick"))
+	assertEqual(t, flag, f.ShorthandLookup('n'))
+	assertEqual(t, "APP_USER", flag.EnvVar)
+	assertEqual(t, true, f.Changed("user"))
+	assertEqual(t, "a", *name)
+
+	var names []string
+	f.VisitAll(func(flag *zflag.Flag) {
+		names = append(names, flag.Name)
+	})
+	assertDeepEqual(t, []string{"other", "user"}, names)
+	assertErrMsg(t, `flag(s) "--user", "--other" must be set together, but "--other" not set`, f.Parse([]string{"--user=b"}))
+}
+
+func TestRenameFlagRedefined(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("name", "", "usage")
+	f.String("other", "", "usage", zflag.OptAlias("alias"))
+	defer assertPanic(t)()
+	f.RenameFlag("name", "alias")
+}
+
 func TestAnnotation(t *testing.T) {
 	f := zflag.NewFlagSet("shorthand", zflag.ContinueOnError)
 
@@ -766,6 +929,283 @@ func TestShorthandOnly(t *testing.T) {
 	}
 }
 
+func TestMultipleShorthands(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		expectedErr    string
+		expectedValue  int
+		expectedOutput string
+	}{
+		{
+			name:          "primary shorthand",
+			args:          []string{"-v"},
+			expectedValue: 1,
+		},
+		{
+			name:          "additional shorthand",
+			args:          []string{"-V", "-vV"},
+			expectedValue: 3,
+		},
+		{
+			name:           "deprecated shorthand",
+			args:           []string{"-x"},
+			expectedValue:  1,
+			expectedOutput: "Flag shorthand -x has been deprecated, use -v instead\n",
+		},
+		{
+			name:        "long name without shorthand",
+			args:        []string{"-q"},
+			expectedErr: `unknown shorthand flag: 'q' in -q`,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(&buf)
+			verbose := f.Count("verbose", "usage", zflag.OptShorthands('v', 'V', 'x'), zflag.OptDeprecatedShorthand('x', "use -v instead"))
+
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedValue, *verbose)
+			assertEqual(t, test.expectedOutput, buf.String())
+		})
+	}
+}
+
+func TestMultipleShorthandsLookup(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("verbose", false, "usage", zflag.OptShorthand('v'), zflag.OptShorthands('V'))
+
+	flag := f.Lookup("verbose")
+	assertEqual(t, 'v', flag.Shorthand)
+	assertDeepEqual(t, []rune{'V'}, flag.Shorthands)
+	assertEqual(t, flag, f.ShorthandLookup('v'))
+	assertEqual(t, flag, f.ShorthandLookup('V'))
+
+	defer assertPanic(t)()
+	f.Bool("version", false, "usage", zflag.OptShorthands('x', 'V'))
+}
+
+func TestMultipleShorthandsUsage(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Bool("verbose", false, "verbose output", zflag.OptShorthands('v', 'V'))
+	f.Bool("quiet", false, "quiet output", zflag.OptShorthands('q', 's'), zflag.OptShorthandDeprecated("use -s"))
+	f.Bool("all", false, "all output", zflag.OptShorthands('a', 'A'), zflag.OptDeprecatedShorthand('A', "use -a"))
+	f.Bool("debug", false, "debug output", zflag.OptShorthands('d', 'D'), zflag.OptDeprecatedShorthand('d', "removed"), zflag.OptDeprecatedShorthand('D', "removed"))
+
+	expected := []string{
+		`  -a, --all           all output`,
+		`      --debug         debug output`,
+		`  -s, --quiet         quiet output`,
+		`  -v, -V, --verbose   verbose output`,
+	}
+	assertEqual(t, strings.Join(expected, "\n")+"\n", f.FlagUsages())
+}
+
+func TestDashValues(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedErr   string
+		expectedInt   int
+		expectedFloat float64
+		expectedInts  []int
+		expectedFile  string
+		expectedArgs  []string
+	}{
+		{
+			name:        "negative int",
+			args:        []string{"--int", "-5"},
+			expectedInt: -5,
+		},
+		{
+			name:        "negative int shorthand",
+			args:        []string{"-n", "-3"},
+			expectedInt: -3,
+		},
+		{
+			name:          "negative float",
+			args:          []string{"--float", "-.5"},
+			expectedFloat: -0.5,
+		},
+		{
+			name:         "negative int slice",
+			args:         []string{"--ints", "-7", "--ints", "-8"},
+			expectedInts: []int{-7, -8},
+		},
+		{
+			name:        "digit shorthand is a flag",
+			args:        []string{"--int", "-1"},
+			expectedErr: "flag needs an argument: --int",
+		},
+		{
+			name:        "not a number",
+			args:        []string{"--int", "-x"},
+			expectedErr: "flag needs an argument: --int",
+		},
+		{
+			name:        "string does not accept dash values",
+			args:        []string{"--name", "-x"},
+			expectedErr: "flag needs an argument: --name",
+		},
+		{
+			name:         "opt in to dash values",
+			args:         []string{"--file", "-", "arg"},
+			expectedFile: "-",
+			expectedArgs: []string{"arg"},
+		},
+		{
+			name:         "opt in to dash values shorthand",
+			args:         []string{"-f", "-x"},
+			expectedFile: "-x",
+		},
+		{
+			name:        "double dash is not a value",
+			args:        []string{"--file", "--"},
+			expectedErr: "flag needs an argument: --file",
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			i := f.Int("int", 0, "usage", zflag.OptShorthand('n'))
+			fl := f.Float64("float", 0, "usage")
+			is := f.IntSlice("ints", nil, "usage")
+			f.String("name", "", "usage")
+			file := f.String("file", "", "usage", zflag.OptShorthand('f'), zflag.OptAllowDashValue())
+			f.Bool("one", false, "usage", zflag.OptShorthand('1'))
+
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedInt, *i)
+			assertEqual(t, test.expectedFloat, *fl)
+			if test.expectedInts != nil {
+				assertDeepEqual(t, test.expectedInts, *is)
+			}
+			assertEqual(t, test.expectedFile, *file)
+			if test.expectedArgs == nil {
+				test.expectedArgs = []string{}
+			}
+			assertDeepEqual(t, test.expectedArgs, f.Args())
+		})
+	}
+}
+
+func TestNoOptDefault(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedErr   string
+		expectedColor string
+		expectedJobs  int
+		expectedArgs  []string
+	}{
+		{
+			name:          "default",
+			expectedColor: "never",
+			expectedJobs:  1,
+		},
+		{
+			name:          "no value",
+			args:          []string{"--color", "--jobs"},
+			expectedColor: "auto",
+			expectedJobs:  -1,
+		},
+		{
+			name:          "next arg is not consumed",
+			args:          []string{"--color", "always", "-j", "4"},
+			expectedColor: "auto",
+			expectedJobs:  -1,
+			expectedArgs:  []string{"always", "4"},
+		},
+		{
+			name:          "inline value",
+			args:          []string{"--color=always", "-j=4"},
+			expectedColor: "always",
+			expectedJobs:  4,
+		},
+		{
+			name:          "combined shorthands",
+			args:          []string{"-jv"},
+			expectedColor: "never",
+			expectedJobs:  -1,
+		},
+		{
+			name:        "invalid inline value",
+			args:        []string{"--jobs=x"},
+			expectedErr: `invalid argument "x" for "-j, --jobs" flag: strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			color := f.String("color", "never", "usage", zflag.OptNoOptDefault("auto"))
+			jobs := f.Int("jobs", 1, "usage", zflag.OptShorthand('j'), zflag.OptNoOptDefault("-1"))
+			f.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedColor, *color)
+			assertEqual(t, test.expectedJobs, *jobs)
+			if test.expectedArgs != nil {
+				assertDeepEqual(t, test.expectedArgs, f.Args())
+			}
+		})
+	}
+}
+
+func TestNoOptDefaultUsage(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("color", "never", "colorize `WHEN`", zflag.OptNoOptDefault("auto"))
+	f.Int("jobs", 1, "number of jobs", zflag.OptShorthand('j'), zflag.OptNoOptDefault("-1"))
+	f.Bool("verbose", false, "verbose output", zflag.OptNoOptDefault("true"))
+
+	expected := []string{
+		`      --color[=WHEN]   colorize WHEN (default "never")`,
+		`  -j, --jobs[=int]     number of jobs (default 1)`,
+		`      --verbose        verbose output`,
+	}
+	assertEqual(t, strings.Join(expected, "\n")+"\n", f.FlagUsages())
+}
+
+func TestOptNoOptDefaultEmpty(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	defer assertPanic(t)()
+	f.String("color", "", "usage", zflag.OptNoOptDefault(""))
+}
+
 func TestShorthandLookup(t *testing.T) {
 	f := zflag.NewFlagSet("shorthand", zflag.ContinueOnError)
 	if f.Parsed() {
@@ -831,6 +1271,649 @@ func TestFlagSetParse(t *testing.T) {
 	testParse(zflag.NewFlagSet("test", zflag.ContinueOnError), t)
 }
 
+func TestAbbreviations(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		disabled         bool
+		expectedErr      string
+		expectedVerbose  bool
+		expectedVersion  string
+		expectedDebug    bool
+		expectedUnknowns []string
+	}{
+		{
+			name:            "unique prefix",
+			args:            []string{"--verb", "--vers=1.0"},
+			expectedVerbose: true,
+			expectedVersion: "1.0",
+		},
+		{
+			name:            "value in next arg",
+			args:            []string{"--vers", "1.0"},
+			expectedVersion: "1.0",
+		},
+		{
+			name:            "exact match wins",
+			args:            []string{"--debug"},
+			expectedVersion: "",
+			expectedDebug:   true,
+		},
+		{
+			name:            "negated prefix",
+			args:            []string{"--verbose", "--no-verb"},
+			expectedVerbose: false,
+		},
+		{
+			name:        "negated value not allowed",
+			args:        []string{"--no-verb=true"},
+			expectedErr: "flag cannot have a value: --no-verb=true",
+		},
+		{
+			name:        "ambiguous prefix",
+			args:        []string{"--ver"},
+			expectedErr: "ambiguous flag: --ver could match --verbose, --version",
+		},
+		{
+			name:        "ambiguous with negative",
+			args:        []string{"--no"},
+			expectedErr: "ambiguous flag: --no could match --no-debug, --no-verbose",
+		},
+		{
+			name:        "shorthand only flags are not matched",
+			args:        []string{"--qui"},
+			expectedErr: "unknown flag: --qui",
+		},
+		{
+			name:        "disabled",
+			args:        []string{"--verb"},
+			disabled:    true,
+			expectedErr: "unknown flag: --verb, did you mean --verbose?",
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.AllowAbbreviations = !test.disabled
+			verbose := f.Bool("verbose", false, "usage", zflag.OptAddNegative())
+			version := f.String("version", "", "usage")
+			debug := f.Bool("debug", false, "usage", zflag.OptAddNegative())
+			f.Bool("debugger", false, "usage")
+			f.Bool("quiet", false, "usage", zflag.OptShorthand('q'), zflag.OptShorthandOnly())
+
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedVerbose, *verbose)
+			assertEqual(t, test.expectedVersion, *version)
+			assertEqual(t, test.expectedDebug, *debug)
+		})
+	}
+}
+
+func TestParseErrorTypes(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedErr   string
+		expectedFlag  string
+		expectedToken string
+		expectedIndex int
+	}{
+		{
+			name:          "bad flag syntax",
+			args:          []string{"arg", "---name"},
+			expectedErr:   "bad flag syntax: ---name",
+			expectedToken: "---name",
+			expectedIndex: 1,
+		},
+		{
+			name:          "unknown shorthand",
+			args:          []string{"--name", "value", "-vx"},
+			expectedErr:   "unknown shorthand flag: 'x' in -x",
+			expectedFlag:  "-x",
+			expectedToken: "-vx",
+			expectedIndex: 2,
+		},
+		{
+			name:          "long flag needs an argument",
+			args:          []string{"-v", "--name"},
+			expectedErr:   "flag needs an argument: --name",
+			expectedFlag:  "--name",
+			expectedToken: "--name",
+			expectedIndex: 1,
+		},
+		{
+			name:          "shorthand flag needs an argument",
+			args:          []string{"--name=value", "-vn"},
+			expectedErr:   "flag needs an argument: 'n' in -n",
+			expectedFlag:  "-n",
+			expectedToken: "-vn",
+			expectedIndex: 1,
+		},
+		{
+			name:          "flag cannot have a value",
+			args:          []string{"-n", "value", "--no-verbose=true"},
+			expectedErr:   "flag cannot have a value: --no-verbose=true",
+			expectedFlag:  "--no-verbose",
+			expectedToken: "--no-verbose=true",
+			expectedIndex: 2,
+		},
+		{
+			name:          "unknown flag",
+			args:          []string{"-v", "--nmae=value"},
+			expectedErr:   "unknown flag: --nmae, did you mean --name?",
+			expectedToken: "--nmae=value",
+			expectedIndex: 1,
+		},
+		{
+			name:          "invalid value",
+			args:          []string{"-n", "value", "--verbose=maybe"},
+			expectedErr:   `invalid argument "maybe" for "-v, --verbose" flag: strconv.ParseBool: parsing "maybe": invalid syntax`,
+			expectedToken: "--verbose=maybe",
+			expectedIndex: 2,
+		},
+		{
+			name:          "invalid shorthand value",
+			args:          []string{"-n", "value", "-v=maybe"},
+			expectedErr:   `invalid argument "maybe" for "-v, --verbose" flag: strconv.ParseBool: parsing "maybe": invalid syntax`,
+			expectedToken: "-v=maybe",
+			expectedIndex: 2,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.Bool("verbose", false, "usage", zflag.OptShorthand('v'), zflag.OptAddNegative())
+			f.String("name", "", "usage", zflag.OptShorthand('n'))
+
+			err := f.Parse(test.args)
+			assertErrMsg(t, test.expectedErr, err)
+
+			var flag, token string
+			var index int
+			var (
+				badSyntaxErr        zflag.BadFlagSyntaxError
+				unknownShorthandErr zflag.UnknownShorthandFlagError
+				needsArgumentErr    zflag.FlagNeedsArgumentError
+				valueNotAllowedErr  zflag.FlagValueNotAllowedError
+				unknownFlagErr      zflag.UnknownFlagError
+				invalidArgumentErr  zflag.InvalidArgumentError
+			)
+			switch {
+			case errors.As(err, &badSyntaxErr):
+				token, index = badSyntaxErr.Token, badSyntaxErr.Index
+			case errors.As(err, &unknownShorthandErr):
+				flag, token, index = unknownShorthandErr.Flag, unknownShorthandErr.Token, unknownShorthandErr.Index
+			case errors.As(err, &needsArgumentErr):
+				flag, token, index = needsArgumentErr.Flag, needsArgumentErr.Token, needsArgumentErr.Index
+			case errors.As(err, &valueNotAllowedErr):
+				flag, token, index = valueNotAllowedErr.Flag, valueNotAllowedErr.Token, valueNotAllowedErr.Index
+			case errors.As(err, &unknownFlagErr):
+				token, index = unknownFlagErr.Token, unknownFlagErr.Index
+			case errors.As(err, &invalidArgumentErr):
+				token, index = invalidArgumentErr.Token, invalidArgumentErr.Index
+			default:
+				t.Fatalf("expected a typed parse error, got %T", errors.Unwrap(err))
+			}
+			assertEqual(t, test.expectedFlag, flag)
+			assertEqual(t, test.expectedToken, token)
+			assertEqual(t, test.expectedIndex, index)
+		})
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name: "no errors",
+			args: []string{"--count=1", "--name=a"},
+		},
+		{
+			name: "all errors",
+			args: []string{"--count=abc", "--unknown", "-x", "arg", "--count=2", "--name"},
+			expectedErr: `invalid argument "abc" for "--count" flag: strconv.ParseInt: parsing "abc": invalid syntax; ` +
+				`unknown flag: --unknown; ` +
+				`unknown shorthand flag: 'x' in -x; ` +
+				`flag needs an argument: --name; ` +
+				`required flag(s) "--name" not set`,
+		},
+		{
+			name:        "validation errors only",
+			args:        []string{"--count=1"},
+			expectedErr: `required flag(s) "--name" not set`,
+		},
+		{
+			name:        "help",
+			args:        []string{"--count=abc", "--help", "--unknown"},
+			expectedErr: zflag.ErrHelp.Error(),
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.CollectErrors = true
+			count := f.Int("count", 0, "usage")
+			f.String("name", "", "usage", zflag.OptRequired())
+
+			err := f.Parse(test.args)
+			if test.expectedErr == "" {
+				assertNoErr(t, err)
+				assertEqual(t, 1, *count)
+				return
+			}
+			assertErrMsg(t, test.expectedErr, err)
+		})
+	}
+}
+
+func TestCollectErrorsTypes(t *testing.T) {
+	var output bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&output)
+	f.CollectErrors = true
+	count := f.Int("count", 0, "usage")
+	f.String("name", "", "usage", zflag.OptRequired())
+
+	err := f.Parse([]string{"--count=abc", "--unknown", "--count=2"})
+	var parseErrs zflag.ParseErrors
+	if !errors.As(err, &parseErrs) {
+		t.Fatalf("expected ParseErrors, got %v", err)
+	}
+	assertEqual(t, 3, len(parseErrs))
+	assertEqual(t, 2, *count)
+
+	var invalidErr zflag.InvalidArgumentError
+	if !errors.As(parseErrs[0], &invalidErr) {
+		t.Fatalf("expected an InvalidArgumentError, got %v", parseErrs[0])
+	}
+	var unknownErr zflag.UnknownFlagError
+	if !errors.As(parseErrs[1], &unknownErr) {
+		t.Fatalf("expected an UnknownFlagError, got %v", parseErrs[1])
+	}
+	var missingErr zflag.MissingFlagsError
+	if !errors.As(parseErrs[2], &missingErr) {
+		t.Fatalf("expected a MissingFlagsError, got %v", parseErrs[2])
+	}
+
+	assertEqual(t, 1, strings.Count(output.String(), "Usage of test:"))
+	assertEqual(t, true, strings.HasSuffix(output.String(), "\n"+strings.Join([]string{
+		`invalid argument "abc" for "--count" flag: strconv.ParseInt: parsing "abc": invalid syntax`,
+		`unknown flag: --unknown`,
+		`required flag(s) "--name" not set`,
+	}, "\n")+"\n"))
+}
+
+func TestParseErrorsAllowListCategories(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		allowList       zflag.ParseErrorsAllowList
+		expectedErr     string
+		expectedIgnored []string
+		expectedCount   int
+		expectedOutput  string
+	}{
+		{
+			name:        "invalid value",
+			args:        []string{"--count=abc"},
+			expectedErr: `invalid argument "abc" for "-c, --count" flag: strconv.ParseInt: parsing "abc": invalid syntax`,
+		},
+		{
+			name:      "invalid value allowed",
+			args:      []string{"--count=abc", "--name=a", "-c", "x"},
+			allowList: zflag.ParseErrorsAllowList{InvalidValues: []string{"count"}},
+			expectedIgnored: []string{
+				`invalid argument "abc" for "-c, --count" flag: strconv.ParseInt: parsing "abc": invalid syntax`,
+				`invalid argument "x" for "-c, --count" flag: strconv.ParseInt: parsing "x": invalid syntax`,
+			},
+			expectedCount: 5,
+		},
+		{
+			name:        "invalid value allowed for other flag",
+			args:        []string{"--count=abc"},
+			allowList:   zflag.ParseErrorsAllowList{InvalidValues: []string{"name"}},
+			expectedErr: `invalid argument "abc" for "-c, --count" flag: strconv.ParseInt: parsing "abc": invalid syntax`,
+		},
+		{
+			name:            "invalid value allowed for all flags",
+			args:            []string{"--count=abc", "--count=2"},
+			allowList:       zflag.ParseErrorsAllowList{InvalidValues: []string{"*"}},
+			expectedIgnored: []string{`invalid argument "abc" for "-c, --count" flag: strconv.ParseInt: parsing "abc": invalid syntax`},
+			expectedCount:   2,
+		},
+		{
+			name:        "missing argument",
+			args:        []string{"--name"},
+			expectedErr: "flag needs an argument: --name",
+		},
+		{
+			name:            "missing argument allowed",
+			args:            []string{"--count", "2", "--name", "-c"},
+			allowList:       zflag.ParseErrorsAllowList{MissingArguments: true},
+			expectedIgnored: []string{"flag needs an argument: --name", "flag needs an argument: 'c' in -c"},
+			expectedCount:   2,
+		},
+		{
+			name:          "deprecated flag",
+			args:          []string{"--old", "-o"},
+			expectedCount: 5,
+			expectedOutput: "Flag --old has been deprecated, use --count\n" +
+				"Flag shorthand -o has been deprecated, use -c\n" +
+				"Flag --old has been deprecated, use --count\n",
+		},
+		{
+			name:      "deprecated flag allowed",
+			args:      []string{"--old", "-o"},
+			allowList: zflag.ParseErrorsAllowList{DeprecatedFlags: true},
+			expectedIgnored: []string{
+				"flag --old has been deprecated, use --count",
+				"flag shorthand -o has been deprecated, use -c",
+				"flag --old has been deprecated, use --count",
+			},
+			expectedCount: 5,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var output bytes.Buffer
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(&output)
+			f.ParseErrorsAllowList = test.allowList
+			count := f.Int("count", 5, "usage", zflag.OptShorthand('c'))
+			f.String("name", "", "usage")
+			f.Bool("old", false, "usage", zflag.OptDeprecated("use --count"), zflag.OptShorthand('o'), zflag.OptShorthandDeprecated("use -c"))
+
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedCount, *count)
+			assertEqual(t, test.expectedOutput, output.String())
+
+			var ignored []string
+			for _, err := range f.GetIgnoredErrors() {
+				ignored = append(ignored, err.Error())
+			}
+			assertDeepEqual(t, test.expectedIgnored, ignored)
+		})
+	}
+}
+
+func TestUnknownFlagSuggestions(t *testing.T) {
+	tests := []struct {
+		name                string
+		args                []string
+		distance            int
+		expectedErr         string
+		expectedSuggestions []string
+	}{
+		{
+			name:                "typo",
+			args:                []string{"--verbos"},
+			expectedErr:         "unknown flag: --verbos, did you mean --verbose?",
+			expectedSuggestions: []string{"--verbose"},
+		},
+		{
+			name:                "case insensitive",
+			args:                []string{"--VERBOSE"},
+			expectedErr:         "unknown flag: --VERBOSE, did you mean --verbose?",
+			expectedSuggestions: []string{"--verbose"},
+		},
+		{
+			name:                "multiple suggestions",
+			args:                []string{"--colr"},
+			expectedErr:         "unknown flag: --colr, did you mean one of --color, --colour?",
+			expectedSuggestions: []string{"--color", "--colour"},
+		},
+		{
+			name:                "prefix",
+			args:                []string{"--out"},
+			expectedErr:         "unknown flag: --out, did you mean --output-format?",
+			expectedSuggestions: []string{"--output-format"},
+		},
+		{
+			name:                "shorthand",
+			args:                []string{"--V"},
+			expectedErr:         "unknown flag: -V, did you mean -v?",
+			expectedSuggestions: []string{"-v"},
+		},
+		{
+			name:        "no close match",
+			args:        []string{"--unrelated"},
+			expectedErr: "unknown flag: --unrelated",
+		},
+		{
+			name:        "hidden flags are not suggested",
+			args:        []string{"--secre"},
+			expectedErr: "unknown flag: --secre",
+		},
+		{
+			name:                "larger distance",
+			args:                []string{"--vrbse"},
+			distance:            3,
+			expectedErr:         "unknown flag: --vrbse, did you mean --verbose?",
+			expectedSuggestions: []string{"--verbose"},
+		},
+		{
+			name:        "disabled",
+			args:        []string{"--verbos"},
+			distance:    -1,
+			expectedErr: "unknown flag: --verbos",
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.SuggestionsMaxDistance = test.distance
+			f.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+			f.String("color", "", "usage", zflag.OptAlias("colour"))
+			f.String("output-format", "", "usage")
+			f.String("secret", "", "usage", zflag.OptHidden())
+
+			err := f.Parse(test.args)
+			assertErrMsg(t, test.expectedErr, err)
+
+			var unknownErr zflag.UnknownFlagError
+			if !errors.As(err, &unknownErr) {
+				t.Fatalf("expected an UnknownFlagError, got %v", err)
+			}
+			assertDeepEqual(t, test.expectedSuggestions, unknownErr.Suggestions)
+		})
+	}
+}
+
+func TestUnknownShorthandSuggestions(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+	f.Bool("all", false, "usage", zflag.OptShorthand('a'))
+
+	err := f.Parse([]string{"-aV"})
+	assertErrMsg(t, "unknown shorthand flag: 'V' in -V, did you mean -v?", err)
+	var unknownErr zflag.UnknownShorthandFlagError
+	if !errors.As(err, &unknownErr) {
+		t.Fatalf("expected an UnknownShorthandFlagError, got %v", err)
+	}
+	assertDeepEqual(t, []string{"-v"}, unknownErr.Suggestions)
+
+	f.SuggestionsMaxDistance = -1
+	assertErrMsg(t, "unknown shorthand flag: 'V' in -V", f.Parse([]string{"-V"}))
+	assertErrMsg(t, "unknown shorthand flag: 'x' in -x", f.Parse([]string{"-x"}))
+}
+
+func TestAmbiguousFlagError(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.AllowAbbreviations = true
+	f.Bool("verbose", false, "usage")
+	f.Bool("version", false, "usage")
+
+	var ambiguousErr zflag.AmbiguousFlagError
+	err := f.Parse([]string{"--v"})
+	if !errors.As(err, &ambiguousErr) {
+		t.Fatalf("expected an AmbiguousFlagError, got %v", err)
+	}
+	assertErrMsg(t, "ambiguous flag: --v could match --verbose, --version", err)
+	assertEqual(t, "--v", ambiguousErr.Flag)
+	assertDeepEqual(t, []string{"--verbose", "--version"}, ambiguousErr.Candidates)
+}
+
+func TestAliases(t *testing.T) {
+	tests := []struct {
+		name            string
+		args            []string
+		expectedName    string
+		expectedVerbose bool
+	}{
+		{
+			name:         "name",
+			args:         []string{"--name=a"},
+			expectedName: "a",
+		},
+		{
+			name:         "alias",
+			args:         []string{"--old-name", "a"},
+			expectedName: "a",
+		},
+		{
+			name:         "normalized alias",
+			args:         []string{"--older_name=a"},
+			expectedName: "a",
+		},
+		{
+			name:            "negated alias",
+			args:            []string{"--verbose", "--no-loud"},
+			expectedVerbose: false,
+		},
+		{
+			name:            "alias abbreviation",
+			args:            []string{"--lo"},
+			expectedVerbose: true,
+		},
+		{
+			name:            "abbreviation of name and alias",
+			args:            []string{"--verbose=false", "--v"},
+			expectedVerbose: true,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.AllowAbbreviations = true
+			name := f.String("name", "", "usage", zflag.OptAlias("old-name", "older-name"))
+			verbose := f.Bool("verbose", false, "usage", zflag.OptAddNegative(), zflag.OptAlias("verbose-output", "loud"))
+			f.SetNormalizeFunc(wordSepNormalizeFunc)
+
+			assertNoErr(t, f.Parse(test.args))
+			assertEqual(t, test.expectedName, *name)
+			assertEqual(t, test.expectedVerbose, *verbose)
+		})
+	}
+}
+
+func TestAliasLookup(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	name := f.String("name", "", "usage", zflag.OptAlias("old-name"))
+
+	assertEqual(t, f.Lookup("name"), f.Lookup("old-name"))
+	assertNoErr(t, f.Set("old-name", "a"))
+	assertEqual(t, "a", *name)
+	assertEqual(t, true, f.Changed("name"))
+	assertEqual(t, true, f.Changed("old-name"))
+	assertEqual(t, 1, f.NFlag())
+
+	f.RemoveFlag("old-name")
+	assertEqual(t, (*zflag.Flag)(nil), f.Lookup("name"))
+	assertEqual(t, (*zflag.Flag)(nil), f.Lookup("old-name"))
+}
+
+func TestAliasRedefined(t *testing.T) {
+	tests := []struct {
+		name    string
+		defined []string
+		flag    string
+		aliases []string
+	}{
+		{name: "alias of flag name", defined: []string{"name"}, flag: "other", aliases: []string{"name"}},
+		{name: "flag name of alias", defined: []string{"name"}, flag: "old-name"},
+		{name: "alias of own name", flag: "name", aliases: []string{"name"}},
+		{name: "duplicate alias", flag: "name", aliases: []string{"old", "old"}},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			for _, name := range test.defined {
+				f.String(name, "", "usage", zflag.OptAlias("old-"+name))
+			}
+			defer assertPanic(t)()
+			f.String(test.flag, "", "usage", zflag.OptAlias(test.aliases...))
+		})
+	}
+}
+
+func TestAliasUsage(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("name", "", "the name", zflag.OptAlias("old-name", "older-name"), zflag.OptShowAliases())
+	f.String("hidden", "", "hidden aliases", zflag.OptAlias("old-hidden"))
+
+	expected := []string{
+		`      --hidden string   hidden aliases`,
+		`      --name string     the name (aliases: --old-name, --older-name)`,
+	}
+	assertEqual(t, strings.Join(expected, "\n")+"\n", f.FlagUsages())
+}
+
 func TestChangedHelper(t *testing.T) {
 	f := zflag.NewFlagSet("changedtest", zflag.ContinueOnError)
 	f.Bool("changed", false, "changed bool")
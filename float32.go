@@ -9,33 +9,50 @@ import (
 )
 
 // -- float32 Value
-type float32Value float32
+type float32Value struct {
+	value    *float32
+	defValue float32
+}
 
 var _ Value = (*float32Value)(nil)
 var _ Getter = (*float32Value)(nil)
 var _ Typed = (*float32Value)(nil)
+var _ Cloner = (*float32Value)(nil)
+var _ Resetter = (*float32Value)(nil)
 
 func newFloat32Value(val float32, p *float32) *float32Value {
 	*p = val
-	return (*float32Value)(p)
+	return &float32Value{value: p, defValue: val}
 }
 
 func (f *float32Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseFloat(val, 32)
-	*f = float32Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*f.value = float32(v)
+	return nil
 }
 
 func (f *float32Value) Get() interface{} {
-	return float32(*f)
+	return *f.value
 }
 
 func (f *float32Value) Type() string {
 	return "float32"
 }
 
-func (f *float32Value) String() string { return strconv.FormatFloat(float64(*f), 'g', -1, 32) }
+func (f *float32Value) Clone() Value {
+	value := *f.value
+	return &float32Value{value: &value, defValue: f.defValue}
+}
+
+func (f *float32Value) Reset() {
+	*f.value = f.defValue
+}
+
+func (f *float32Value) String() string { return strconv.FormatFloat(float64(*f.value), 'g', -1, 32) }
 
 // GetFloat32 return the float32 value of a flag with the given name
 func (fs *FlagSet) GetFloat32(name string) (float32, error) {
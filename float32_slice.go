@@ -11,19 +11,23 @@ import (
 
 // -- float32Slice Value
 type float32SliceValue struct {
-	value   *[]float32
-	changed bool
+	value    *[]float32
+	changed  bool
+	defaults []float32
 }
 
 var _ Value = (*float32SliceValue)(nil)
 var _ Getter = (*float32SliceValue)(nil)
 var _ SliceValue = (*float32SliceValue)(nil)
 var _ Typed = (*float32SliceValue)(nil)
+var _ Cloner = (*float32SliceValue)(nil)
+var _ Resetter = (*float32SliceValue)(nil)
 
 func newFloat32SliceValue(val []float32, p *[]float32) *float32SliceValue {
 	isv := new(float32SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *float32SliceValue) Type() string {
 	return "float32Slice"
 }
 
+func (s *float32SliceValue) Clone() Value {
+	value := append([]float32(nil), *s.value...)
+	return &float32SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *float32SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *float32SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
@@ -9,33 +9,50 @@ import (
 )
 
 // -- float64 Value
-type float64Value float64
+type float64Value struct {
+	value    *float64
+	defValue float64
+}
 
 var _ Value = (*float64Value)(nil)
 var _ Getter = (*float64Value)(nil)
 var _ Typed = (*float64Value)(nil)
+var _ Cloner = (*float64Value)(nil)
+var _ Resetter = (*float64Value)(nil)
 
 func newFloat64Value(val float64, p *float64) *float64Value {
 	*p = val
-	return (*float64Value)(p)
+	return &float64Value{value: p, defValue: val}
 }
 
 func (f *float64Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseFloat(val, 64)
-	*f = float64Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*f.value = v
+	return nil
 }
 
 func (f *float64Value) Get() interface{} {
-	return float64(*f)
+	return *f.value
 }
 
 func (f *float64Value) Type() string {
 	return "float64"
 }
 
-func (f *float64Value) String() string { return strconv.FormatFloat(float64(*f), 'g', -1, 64) }
+func (f *float64Value) Clone() Value {
+	value := *f.value
+	return &float64Value{value: &value, defValue: f.defValue}
+}
+
+func (f *float64Value) Reset() {
+	*f.value = f.defValue
+}
+
+func (f *float64Value) String() string { return strconv.FormatFloat(*f.value, 'g', -1, 64) }
 
 // GetFloat64 return the float64 value of a flag with the given name
 func (fs *FlagSet) GetFloat64(name string) (float64, error) {
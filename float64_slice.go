@@ -11,19 +11,23 @@ import (
 
 // -- float64Slice Value
 type float64SliceValue struct {
-	value   *[]float64
-	changed bool
+	value    *[]float64
+	changed  bool
+	defaults []float64
 }
 
 var _ Value = (*float64SliceValue)(nil)
 var _ Getter = (*float64SliceValue)(nil)
 var _ SliceValue = (*float64SliceValue)(nil)
 var _ Typed = (*float64SliceValue)(nil)
+var _ Cloner = (*float64SliceValue)(nil)
+var _ Resetter = (*float64SliceValue)(nil)
 
 func newFloat64SliceValue(val []float64, p *[]float64) *float64SliceValue {
 	isv := new(float64SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *float64SliceValue) Type() string {
 	return "float64Slice"
 }
 
+func (s *float64SliceValue) Clone() Value {
+	value := append([]float64(nil), *s.value...)
+	return &float64SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *float64SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *float64SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
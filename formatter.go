@@ -5,6 +5,7 @@ package zflag
 
 import (
 	"fmt"
+	"strings"
 )
 
 // FlagUsageFormatter is a function type that prints the usage for a single Flag.
@@ -17,8 +18,13 @@ type FlagUsageFormatter func(*Flag) (string, string)
 
 func defaultUsageFormatter(flag *Flag) (string, string) {
 	left := "  "
-	if flag.Shorthand != 0 && flag.ShorthandDeprecated == "" {
-		left += fmt.Sprintf("-%c", flag.Shorthand)
+	if shorthands := flag.visibleShorthands(); len(shorthands) > 0 {
+		for i, shorthand := range shorthands {
+			if i > 0 {
+				left += ", "
+			}
+			left += fmt.Sprintf("-%c", shorthand)
+		}
 		if !flag.ShorthandOnly {
 			left += ", "
 		}
@@ -26,20 +32,30 @@ func defaultUsageFormatter(flag *Flag) (string, string) {
 		left += "    "
 	}
 	left += "--"
-	if _, isBoolFlag := flag.Value.(BoolFlag); isBoolFlag && flag.AddNegative {
+	_, isBoolFlag := flag.Value.(BoolFlag)
+	if isBoolFlag && flag.AddNegative {
 		left += "[no-]"
 	}
 	left += flag.Name
 
 	varname, usage := UnquoteUsage(flag)
-	if varname != "" {
+	switch {
+	case varname != "" && flag.NoOptDefVal != "" && !isBoolFlag:
+		left += "[=" + varname + "]"
+	case varname != "":
 		left += " " + varname
 	}
 
 	right := usage
+	if len(flag.Choices) > 0 {
+		right += fmt.Sprintf(" (one of: %s)", strings.Join(flag.Choices, ", "))
+	}
 	if flag.Required {
 		right += " (required)"
 	}
+	if flag.ShowAliases && len(flag.Aliases) > 0 {
+		right += fmt.Sprintf(" (aliases: --%s)", strings.Join(flag.Aliases, ", --"))
+	}
 
 	if !flag.DisablePrintDefault && !flag.DefaultIsZeroValue() {
 		if v, ok := flag.Value.(Typed); ok && v.Type() == "string" {
@@ -48,9 +64,33 @@ func defaultUsageFormatter(flag *Flag) (string, string) {
 			right += fmt.Sprintf(" (default %s)", flag.DefValue)
 		}
 	}
+	if flag.EnvVar != "" {
+		right += fmt.Sprintf(" [$%s]", flag.EnvVar)
+	}
 	if len(flag.Deprecated) != 0 {
 		right += fmt.Sprintf(" (DEPRECATED: %s)", flag.Deprecated)
 	}
+	if cv, ok := flag.Value.(ChoiceValue); ok {
+		right += choiceDescriptions(cv)
+	}
 
 	return left, right
 }
+
+// choiceDescriptions returns a line for every choice with a description.
+func choiceDescriptions(cv ChoiceValue) string {
+	width := 0
+	for _, choice := range cv.Choices() {
+		if cv.ChoiceDescription(choice) != "" && len(choice) > width {
+			width = len(choice)
+		}
+	}
+
+	var lines string
+	for _, choice := range cv.Choices() {
+		if description := cv.ChoiceDescription(choice); description != "" {
+			lines += fmt.Sprintf("\n  %-*s  %s", width, choice, description)
+		}
+	}
+	return lines
+}
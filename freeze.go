@@ -0,0 +1,51 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import "fmt"
+
+// Freeze freezes the FlagSet. Defining, removing or grouping flags, adding
+// validators or providers and changing the normalize function, the env
+// prefix or the source precedence panics afterwards, and setting a flag returns a FrozenFlagSetError,
+// unless the flag is Mutable. Set FreezeAfterParse to freeze the FlagSet
+// when parsing is done.
+func (fs *FlagSet) Freeze() {
+	defer fs.lock()()
+	fs.frozen = true
+}
+
+// Freeze freezes the command-line flags.
+func Freeze() {
+	CommandLine.Freeze()
+}
+
+// Frozen reports whether the FlagSet is frozen.
+func (fs *FlagSet) Frozen() bool {
+	defer fs.rlock()()
+	return fs.frozen
+}
+
+// Frozen reports whether the command-line flags are frozen.
+func Frozen() bool {
+	return CommandLine.Frozen()
+}
+
+// checkSettable returns a FrozenFlagSetError if the flag cannot be set because
+// the FlagSet is frozen.
+func (fs *FlagSet) checkSettable(flag *Flag) error {
+	if fs.frozen && !flag.Mutable {
+		return FrozenFlagSetError{FlagSet: fs.name, Flag: getFlagWithDashes(flag.Name)}
+	}
+	return nil
+}
+
+// checkNotFrozen panics if the FlagSet is frozen, as the definitions of the
+// flags can no longer be changed.
+func (fs *FlagSet) checkNotFrozen(action string) {
+	if fs.frozen {
+		msg := fmt.Sprintf("unable to %s in %q flagset: flagset is frozen", action, fs.name)
+		fmt.Fprintln(fs.Output(), msg)
+		panic(msg)
+	}
+}
@@ -0,0 +1,129 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestFreeze(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	name := f.String("name", "default", "usage")
+	level := f.String("level", "info", "usage", zflag.OptMutable())
+
+	assertEqual(t, false, f.Frozen())
+	f.Freeze()
+	assertEqual(t, true, f.Frozen())
+
+	err := f.Set("name", "set")
+	assertErrMsg(t, `unable to set flag --name in "test" flagset: flagset is frozen`, err)
+	var frozenErr zflag.FrozenFlagSetError
+	if !errors.As(err, &frozenErr) {
+		t.Fatalf("expected a FrozenFlagSetError, got %v", err)
+	}
+	assertEqual(t, "--name", frozenErr.Flag)
+	assertEqual(t, "default", *name)
+	assertEqual(t, false, f.Changed("name"))
+
+	assertNoErr(t, f.Set("level", "debug"))
+	assertEqual(t, "debug", *level)
+
+	assertErrMsg(t, `unable to set flag --name in "test" flagset: flagset is frozen`, f.Parse([]string{"--name=parsed"}))
+	assertEqual(t, "default", *name)
+	assertErr(t, f.LoadConfig(strings.NewReader("name: config"), zflag.ConfigYAML))
+	assertEqual(t, "default", *name)
+
+	assertErrMsg(t, `unable to reset "test" flagset: flagset is frozen`, f.Reset())
+	assertEqual(t, "debug", *level)
+}
+
+func TestFreezeDefinitions(t *testing.T) {
+	tests := []struct {
+		name        string
+		define      func(f *zflag.FlagSet)
+		expectedMsg string
+	}{
+		{
+			name:        "define flag",
+			define:      func(f *zflag.FlagSet) { f.Bool("late", false, "usage") },
+			expectedMsg: `unable to add flag "late" in "test" flagset: flagset is frozen`,
+		},
+		{
+			name:        "remove flag",
+			define:      func(f *zflag.FlagSet) { f.RemoveFlag("json") },
+			expectedMsg: `unable to remove flag "json" in "test" flagset: flagset is frozen`,
+		},
+		{
+			name:        "flag group",
+			define:      func(f *zflag.FlagSet) { f.MarkMutuallyExclusive("json", "yaml") },
+			expectedMsg: `unable to add a flag group in "test" flagset: flagset is frozen`,
+		},
+		{
+			name:        "requirement",
+			define:      func(f *zflag.FlagSet) { f.RequiredIf(zflag.FlagIsSet("json"), "yaml") },
+			expectedMsg: `unable to add a requirement in "test" flagset: flagset is frozen`,
+		},
+		{
+			name:        "validator",
+			define:      func(f *zflag.FlagSet) { f.AddValidator(func(*zflag.FlagSet) error { return nil }) },
+			expectedMsg: `unable to add a validator in "test" flagset: flagset is frozen`,
+		},
+		{
+			name:        "env prefix",
+			define:      func(f *zflag.FlagSet) { f.SetEnvPrefix("APP") },
+			expectedMsg: `unable to set the env prefix in "test" flagset: flagset is frozen`,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			output := new(strings.Builder)
+			f.SetOutput(output)
+			f.Bool("json", false, "usage")
+			f.Bool("yaml", false, "usage")
+			f.Freeze()
+
+			defer func() {
+				assertEqual(t, test.expectedMsg, recover())
+				assertEqual(t, test.expectedMsg+"\n", output.String())
+			}()
+			test.define(f)
+		})
+	}
+}
+
+func TestFreezeAfterParse(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.FreezeAfterParse = true
+	f.String("name", "default", "usage")
+	f.AddProvider(mapProvider{source: "remote", values: map[string][]string{"name": {"provided"}}})
+
+	assertNoErr(t, f.Parse([]string{}))
+	assertEqual(t, true, f.Frozen())
+	assertEqual(t, "provided", f.Lookup("name").Value.String())
+	assertErr(t, f.Set("name", "set"))
+}
+
+func TestFreezeClone(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.FreezeAfterParse = true
+	f.String("name", "default", "usage")
+	f.Freeze()
+
+	result, err := f.ParseToResult([]string{"--name=parsed"})
+	assertNoErr(t, err)
+	assertEqual(t, "parsed", result.Lookup("name").Value.String())
+	assertEqual(t, true, result.FlagSet().Frozen())
+}
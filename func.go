@@ -8,6 +8,8 @@ type funcValue func(string) error
 
 var _ Value = (*funcValue)(nil)
 var _ Typed = (*funcValue)(nil)
+var _ Cloner = (*funcValue)(nil)
+var _ Resetter = (*funcValue)(nil)
 
 func newFuncValue(fn func(string) error) *funcValue {
 	funcVal := funcValue(fn)
@@ -22,6 +24,14 @@ func (i *funcValue) Type() string {
 	return "string"
 }
 
+func (i *funcValue) Clone() Value {
+	clone := *i
+	return &clone
+}
+
+// Reset does nothing, as the value is only passed to the function.
+func (i *funcValue) Reset() {}
+
 func (i *funcValue) String() string { return "" }
 
 // Func defines a flag with specified name, and usage string.
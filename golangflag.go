@@ -62,6 +62,23 @@ func (v *flagValueWrapper) Type() string {
 	return v.flagType
 }
 
+// cloneGoFlagValue copies a value defined by the flag package, which keeps its
+// value in the variable it points to. Func values are returned as they are, as
+// they have no storage of their own.
+func cloneGoFlagValue(v goflag.Value) (Value, bool) {
+	value := reflect.ValueOf(v)
+	switch {
+	case value.Type().PkgPath() == "flag" && value.Kind() == reflect.Func:
+		return v, true
+	case value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Type().PkgPath() != "flag":
+		return nil, false
+	}
+
+	clone := reflect.New(value.Elem().Type())
+	clone.Elem().Set(value.Elem())
+	return clone.Interface().(Value), true
+}
+
 // FromGoFlag will return a *zflag.Flag given a *flag.Flag
 // If the *flag.Flag.Name was a single character (ex: `v`) it will be accessible
 // with both `-v` and `--v` in flags. If the golang flag was more than a single
@@ -6,6 +6,7 @@ package zflag_test
 import (
 	goflag "flag"
 	"testing"
+	"time"
 
 	"github.com/zulucmd/zflag/v2"
 )
@@ -60,3 +61,44 @@ func TestGoflags(t *testing.T) {
 		t.Fatal("goflag.CommandLine.Parsed() return false after f.Parse() called")
 	}
 }
+
+func TestGoflagsClone(t *testing.T) {
+	goflags := goflag.NewFlagSet("test", goflag.ContinueOnError)
+	name := goflags.String("name", "default", "usage")
+	verbose := goflags.Bool("verbose", false, "usage")
+	timeout := goflags.Duration("timeout", time.Second, "usage")
+	var hooks []string
+	goflags.Func("hook", "usage", func(value string) error {
+		hooks = append(hooks, value)
+		return nil
+	})
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.AddGoFlagSet(goflags)
+
+	clone, err := f.Clone()
+	assertNoErr(t, err)
+	assertNoErr(t, clone.Parse([]string{"--name=clone", "--verbose", "--timeout=2s", "--hook=a"}))
+	assertEqual(t, "clone", clone.MustGetString("name"))
+	assertEqual(t, "2s", clone.Lookup("timeout").Value.String())
+	assertEqual(t, "default", *name)
+	assertEqual(t, false, *verbose)
+	assertEqual(t, time.Second, *timeout)
+	assertDeepEqual(t, []string{"a"}, hooks)
+
+	result, err := f.ParseToResult([]string{"--name=result"})
+	assertNoErr(t, err)
+	assertEqual(t, "result", result.FlagSet().MustGetString("name"))
+	assertEqual(t, "default", *name)
+}
+
+func TestGoflagsCloneCustomValue(t *testing.T) {
+	goflags := goflag.NewFlagSet("test", goflag.ContinueOnError)
+	goflags.Var(new(customValue), "custom", "usage")
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.AddGoFlagSet(goflags)
+
+	_, err := f.Clone()
+	assertErrMsg(t, `unable to clone flag "custom": value of type *zflag_test.customValue does not implement Cloner`, err)
+}
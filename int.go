@@ -9,33 +9,50 @@ import (
 )
 
 // -- int Value
-type intValue int
+type intValue struct {
+	value    *int
+	defValue int
+}
 
 var _ Value = (*intValue)(nil)
 var _ Getter = (*intValue)(nil)
 var _ Typed = (*intValue)(nil)
+var _ Cloner = (*intValue)(nil)
+var _ Resetter = (*intValue)(nil)
 
 func newIntValue(val int, p *int) *intValue {
 	*p = val
-	return (*intValue)(p)
+	return &intValue{value: p, defValue: val}
 }
 
 func (i *intValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseInt(val, 0, 64)
-	*i = intValue(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = int(v)
+	return nil
 }
 
 func (i *intValue) Get() interface{} {
-	return int(*i)
+	return *i.value
 }
 
 func (i *intValue) Type() string {
 	return "int"
 }
 
-func (i *intValue) String() string { return strconv.Itoa(int(*i)) }
+func (i *intValue) Clone() Value {
+	value := *i.value
+	return &intValue{value: &value, defValue: i.defValue}
+}
+
+func (i *intValue) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *intValue) String() string { return strconv.Itoa(*i.value) }
 
 // GetInt return the int value of a flag with the given name
 func (fs *FlagSet) GetInt(name string) (int, error) {
@@ -9,33 +9,50 @@ import (
 )
 
 // -- int16 Value
-type int16Value int16
+type int16Value struct {
+	value    *int16
+	defValue int16
+}
 
 var _ Value = (*int16Value)(nil)
 var _ Getter = (*int16Value)(nil)
 var _ Typed = (*int16Value)(nil)
+var _ Cloner = (*int16Value)(nil)
+var _ Resetter = (*int16Value)(nil)
 
 func newInt16Value(val int16, p *int16) *int16Value {
 	*p = val
-	return (*int16Value)(p)
+	return &int16Value{value: p, defValue: val}
 }
 
 func (i *int16Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseInt(val, 0, 16)
-	*i = int16Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = int16(v)
+	return nil
 }
 
 func (i *int16Value) Get() interface{} {
-	return int16(*i)
+	return *i.value
 }
 
 func (i *int16Value) Type() string {
 	return "int16"
 }
 
-func (i *int16Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+func (i *int16Value) Clone() Value {
+	value := *i.value
+	return &int16Value{value: &value, defValue: i.defValue}
+}
+
+func (i *int16Value) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *int16Value) String() string { return strconv.FormatInt(int64(*i.value), 10) }
 
 // GetInt16 returns the int16 value of a flag with the given name
 func (fs *FlagSet) GetInt16(name string) (int16, error) {
@@ -11,19 +11,23 @@ import (
 
 // -- int16Slice Value
 type int16SliceValue struct {
-	value   *[]int16
-	changed bool
+	value    *[]int16
+	changed  bool
+	defaults []int16
 }
 
 var _ Value = (*int16SliceValue)(nil)
 var _ Getter = (*int16SliceValue)(nil)
 var _ SliceValue = (*int16SliceValue)(nil)
 var _ Typed = (*int16SliceValue)(nil)
+var _ Cloner = (*int16SliceValue)(nil)
+var _ Resetter = (*int16SliceValue)(nil)
 
 func newInt16SliceValue(val []int16, p *[]int16) *int16SliceValue {
 	isv := new(int16SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *int16SliceValue) Type() string {
 	return "int16Slice"
 }
 
+func (s *int16SliceValue) Clone() Value {
+	value := append([]int16(nil), *s.value...)
+	return &int16SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *int16SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *int16SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
@@ -9,33 +9,50 @@ import (
 )
 
 // -- int32 Value
-type int32Value int32
+type int32Value struct {
+	value    *int32
+	defValue int32
+}
 
 var _ Value = (*int32Value)(nil)
 var _ Getter = (*int32Value)(nil)
 var _ Typed = (*int32Value)(nil)
+var _ Cloner = (*int32Value)(nil)
+var _ Resetter = (*int32Value)(nil)
 
 func newInt32Value(val int32, p *int32) *int32Value {
 	*p = val
-	return (*int32Value)(p)
+	return &int32Value{value: p, defValue: val}
 }
 
 func (i *int32Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseInt(val, 0, 32)
-	*i = int32Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = int32(v)
+	return nil
 }
 
 func (i *int32Value) Get() interface{} {
-	return int32(*i)
+	return *i.value
 }
 
 func (i *int32Value) Type() string {
 	return "int32"
 }
 
-func (i *int32Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+func (i *int32Value) Clone() Value {
+	value := *i.value
+	return &int32Value{value: &value, defValue: i.defValue}
+}
+
+func (i *int32Value) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *int32Value) String() string { return strconv.FormatInt(int64(*i.value), 10) }
 
 // GetInt32 return the int32 value of a flag with the given name
 func (fs *FlagSet) GetInt32(name string) (int32, error) {
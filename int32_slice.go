@@ -11,19 +11,23 @@ import (
 
 // -- int32Slice Value
 type int32SliceValue struct {
-	value   *[]int32
-	changed bool
+	value    *[]int32
+	changed  bool
+	defaults []int32
 }
 
 var _ Value = (*int32SliceValue)(nil)
 var _ Getter = (*int32SliceValue)(nil)
 var _ SliceValue = (*int32SliceValue)(nil)
 var _ Typed = (*int32SliceValue)(nil)
+var _ Cloner = (*int32SliceValue)(nil)
+var _ Resetter = (*int32SliceValue)(nil)
 
 func newInt32SliceValue(val []int32, p *[]int32) *int32SliceValue {
 	isv := new(int32SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *int32SliceValue) Type() string {
 	return "int32Slice"
 }
 
+func (s *int32SliceValue) Clone() Value {
+	value := append([]int32(nil), *s.value...)
+	return &int32SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *int32SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *int32SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
@@ -9,33 +9,50 @@ import (
 )
 
 // -- int64 Value
-type int64Value int64
+type int64Value struct {
+	value    *int64
+	defValue int64
+}
 
 var _ Value = (*int64Value)(nil)
 var _ Getter = (*int64Value)(nil)
 var _ Typed = (*int64Value)(nil)
+var _ Cloner = (*int64Value)(nil)
+var _ Resetter = (*int64Value)(nil)
 
 func newInt64Value(val int64, p *int64) *int64Value {
 	*p = val
-	return (*int64Value)(p)
+	return &int64Value{value: p, defValue: val}
 }
 
 func (i *int64Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseInt(val, 0, 64)
-	*i = int64Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = v
+	return nil
 }
 
 func (i *int64Value) Get() interface{} {
-	return int64(*i)
+	return *i.value
 }
 
 func (i *int64Value) Type() string {
 	return "int64"
 }
 
-func (i *int64Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+func (i *int64Value) Clone() Value {
+	value := *i.value
+	return &int64Value{value: &value, defValue: i.defValue}
+}
+
+func (i *int64Value) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *int64Value) String() string { return strconv.FormatInt(*i.value, 10) }
 
 // GetInt64 return the int64 value of a flag with the given name
 func (fs *FlagSet) GetInt64(name string) (int64, error) {
@@ -11,19 +11,23 @@ import (
 
 // -- int64Slice Value
 type int64SliceValue struct {
-	value   *[]int64
-	changed bool
+	value    *[]int64
+	changed  bool
+	defaults []int64
 }
 
 var _ Value = (*int64SliceValue)(nil)
 var _ Getter = (*int64SliceValue)(nil)
 var _ SliceValue = (*int64SliceValue)(nil)
 var _ Typed = (*int64SliceValue)(nil)
+var _ Cloner = (*int64SliceValue)(nil)
+var _ Resetter = (*int64SliceValue)(nil)
 
 func newInt64SliceValue(val []int64, p *[]int64) *int64SliceValue {
 	isv := new(int64SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *int64SliceValue) Type() string {
 	return "int64Slice"
 }
 
+func (s *int64SliceValue) Clone() Value {
+	value := append([]int64(nil), *s.value...)
+	return &int64SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *int64SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *int64SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
@@ -9,33 +9,50 @@ import (
 )
 
 // -- int8 Value
-type int8Value int8
+type int8Value struct {
+	value    *int8
+	defValue int8
+}
 
 var _ Value = (*int8Value)(nil)
 var _ Getter = (*int8Value)(nil)
 var _ Typed = (*int8Value)(nil)
+var _ Cloner = (*int8Value)(nil)
+var _ Resetter = (*int8Value)(nil)
 
 func newInt8Value(val int8, p *int8) *int8Value {
 	*p = val
-	return (*int8Value)(p)
+	return &int8Value{value: p, defValue: val}
 }
 
 func (i *int8Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseInt(val, 0, 8)
-	*i = int8Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = int8(v)
+	return nil
 }
 
 func (i *int8Value) Get() interface{} {
-	return int8(*i)
+	return *i.value
 }
 
 func (i *int8Value) Type() string {
 	return "int8"
 }
 
-func (i *int8Value) String() string { return strconv.FormatInt(int64(*i), 10) }
+func (i *int8Value) Clone() Value {
+	value := *i.value
+	return &int8Value{value: &value, defValue: i.defValue}
+}
+
+func (i *int8Value) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *int8Value) String() string { return strconv.FormatInt(int64(*i.value), 10) }
 
 // GetInt8 return the int8 value of a flag with the given name
 func (fs *FlagSet) GetInt8(name string) (int8, error) {
@@ -11,19 +11,23 @@ import (
 
 // -- int8Slice Value
 type int8SliceValue struct {
-	value   *[]int8
-	changed bool
+	value    *[]int8
+	changed  bool
+	defaults []int8
 }
 
 var _ Value = (*int8SliceValue)(nil)
 var _ Getter = (*int8SliceValue)(nil)
 var _ SliceValue = (*int8SliceValue)(nil)
 var _ Typed = (*int8SliceValue)(nil)
+var _ Cloner = (*int8SliceValue)(nil)
+var _ Resetter = (*int8SliceValue)(nil)
 
 func newInt8SliceValue(val []int8, p *[]int8) *int8SliceValue {
 	isv := new(int8SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *int8SliceValue) Type() string {
 	return "int8Slice"
 }
 
+func (s *int8SliceValue) Clone() Value {
+	value := append([]int8(nil), *s.value...)
+	return &int8SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *int8SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *int8SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
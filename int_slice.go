@@ -11,18 +11,22 @@ import (
 
 // -- intSlice Value
 type intSliceValue struct {
-	value   *[]int
-	changed bool
+	value    *[]int
+	changed  bool
+	defaults []int
 }
 
 var _ Value = (*intSliceValue)(nil)
 var _ Getter = (*intSliceValue)(nil)
 var _ Typed = (*intSliceValue)(nil)
+var _ Cloner = (*intSliceValue)(nil)
+var _ Resetter = (*intSliceValue)(nil)
 
 func newIntSliceValue(val []int, p *[]int) *intSliceValue {
 	isv := new(intSliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -50,6 +54,16 @@ func (s *intSliceValue) Type() string {
 	return "intSlice"
 }
 
+func (s *intSliceValue) Clone() Value {
+	value := append([]int(nil), *s.value...)
+	return &intSliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *intSliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *intSliceValue) String() string {
 	if s.value == nil {
 		return "[]"
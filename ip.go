@@ -10,18 +10,23 @@ import (
 )
 
 // -- net.IP value
-type ipValue net.IP
+type ipValue struct {
+	value    *net.IP
+	defValue net.IP
+}
 
 var _ Value = (*ipValue)(nil)
 var _ Getter = (*ipValue)(nil)
 var _ Typed = (*ipValue)(nil)
+var _ Cloner = (*ipValue)(nil)
+var _ Resetter = (*ipValue)(nil)
 
 func newIPValue(val net.IP, p *net.IP) *ipValue {
 	*p = val
-	return (*ipValue)(p)
+	return &ipValue{value: p, defValue: val}
 }
 
-func (i *ipValue) String() string { return net.IP(*i).String() }
+func (i *ipValue) String() string { return i.value.String() }
 func (i *ipValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	if val == "" {
@@ -31,18 +36,27 @@ func (i *ipValue) Set(val string) error {
 	if ip == nil {
 		return fmt.Errorf("failed to parse IP: %q", val)
 	}
-	*i = ipValue(ip)
+	*i.value = ip
 	return nil
 }
 
 func (i *ipValue) Get() interface{} {
-	return net.IP(*i)
+	return *i.value
 }
 
 func (i *ipValue) Type() string {
 	return "ip"
 }
 
+func (i *ipValue) Clone() Value {
+	value := *i.value
+	return &ipValue{value: &value, defValue: i.defValue}
+}
+
+func (i *ipValue) Reset() {
+	*i.value = i.defValue
+}
+
 // GetIP return the net.IP value of a flag with the given name
 func (fs *FlagSet) GetIP(name string) (net.IP, error) {
 	val, err := fs.getFlagValue(name, "ip")
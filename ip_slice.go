@@ -12,19 +12,23 @@ import (
 
 // -- ipSlice Value
 type ipSliceValue struct {
-	value   *[]net.IP
-	changed bool
+	value    *[]net.IP
+	changed  bool
+	defaults []net.IP
 }
 
 var _ Value = (*ipSliceValue)(nil)
 var _ Getter = (*ipSliceValue)(nil)
 var _ SliceValue = (*ipSliceValue)(nil)
 var _ Typed = (*ipSliceValue)(nil)
+var _ Cloner = (*ipSliceValue)(nil)
+var _ Resetter = (*ipSliceValue)(nil)
 
 func newIPSliceValue(val []net.IP, p *[]net.IP) *ipSliceValue {
 	ipsv := new(ipSliceValue)
 	ipsv.value = p
 	*ipsv.value = val
+	ipsv.defaults = val
 	return ipsv
 }
 
@@ -56,6 +60,16 @@ func (s *ipSliceValue) Type() string {
 	return "ipSlice"
 }
 
+func (s *ipSliceValue) Clone() Value {
+	value := append([]net.IP(nil), *s.value...)
+	return &ipSliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *ipSliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 // String defines a "native" format for this net.IP slice flag value.
 func (s *ipSliceValue) String() string {
 	if s.value == nil {
@@ -11,36 +11,50 @@ import (
 )
 
 // -- net.IPMask value
-type ipMaskValue net.IPMask
+type ipMaskValue struct {
+	value    *net.IPMask
+	defValue net.IPMask
+}
 
 var _ Value = (*ipMaskValue)(nil)
 var _ Getter = (*ipMaskValue)(nil)
 var _ Typed = (*ipMaskValue)(nil)
+var _ Cloner = (*ipMaskValue)(nil)
+var _ Resetter = (*ipMaskValue)(nil)
 
 func newIPMaskValue(val net.IPMask, p *net.IPMask) *ipMaskValue {
 	*p = val
-	return (*ipMaskValue)(p)
+	return &ipMaskValue{value: p, defValue: val}
 }
 
-func (i *ipMaskValue) String() string { return net.IPMask(*i).String() }
+func (i *ipMaskValue) String() string { return i.value.String() }
 func (i *ipMaskValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	ip := ParseIPv4Mask(val)
 	if ip == nil {
 		return fmt.Errorf("failed to parse IP mask: %q", val)
 	}
-	*i = ipMaskValue(ip)
+	*i.value = ip
 	return nil
 }
 
 func (i *ipMaskValue) Get() interface{} {
-	return net.IPMask(*i)
+	return *i.value
 }
 
 func (i *ipMaskValue) Type() string {
 	return "ipMask"
 }
 
+func (i *ipMaskValue) Clone() Value {
+	value := *i.value
+	return &ipMaskValue{value: &value, defValue: i.defValue}
+}
+
+func (i *ipMaskValue) Reset() {
+	*i.value = i.defValue
+}
+
 // ParseIPv4Mask written in IP form (e.g. 255.255.255.0).
 // This function should really belong to the net package.
 func ParseIPv4Mask(s string) net.IPMask {
@@ -9,19 +9,23 @@ import (
 )
 
 // IPNet adapts net.IPNet for use as a flag.
-type ipNetValue net.IPNet
+type ipNetValue struct {
+	value    *net.IPNet
+	defValue net.IPNet
+}
 
 var _ Value = (*ipNetValue)(nil)
 var _ Getter = (*ipNetValue)(nil)
 var _ Typed = (*ipNetValue)(nil)
+var _ Cloner = (*ipNetValue)(nil)
+var _ Resetter = (*ipNetValue)(nil)
 
-func (ipnet ipNetValue) String() string {
-	n := net.IPNet(ipnet)
-	return n.String()
+func (ipnet *ipNetValue) String() string {
+	return ipnet.value.String()
 }
 
 func (ipnet *ipNetValue) Get() interface{} {
-	return net.IPNet(*ipnet)
+	return *ipnet.value
 }
 
 func (ipnet *ipNetValue) Set(value string) error {
@@ -29,7 +33,7 @@ func (ipnet *ipNetValue) Set(value string) error {
 	if err != nil {
 		return err
 	}
-	*ipnet = ipNetValue(*n)
+	*ipnet.value = *n
 	return nil
 }
 
@@ -37,9 +41,18 @@ func (*ipNetValue) Type() string {
 	return "ipNet"
 }
 
+func (ipnet *ipNetValue) Clone() Value {
+	value := *ipnet.value
+	return &ipNetValue{value: &value, defValue: ipnet.defValue}
+}
+
+func (ipnet *ipNetValue) Reset() {
+	*ipnet.value = ipnet.defValue
+}
+
 func newIPNetValue(val net.IPNet, p *net.IPNet) *ipNetValue {
 	*p = val
-	return (*ipNetValue)(p)
+	return &ipNetValue{value: p, defValue: val}
 }
 
 // GetIPNet return the net.IPNet value of a flag with the given name
@@ -11,19 +11,23 @@ import (
 
 // -- ipNetSlice Value
 type ipNetSliceValue struct {
-	value   *[]net.IPNet
-	changed bool
+	value    *[]net.IPNet
+	changed  bool
+	defaults []net.IPNet
 }
 
 var _ Value = (*ipNetSliceValue)(nil)
 var _ Getter = (*ipNetSliceValue)(nil)
 var _ SliceValue = (*ipNetSliceValue)(nil)
 var _ Typed = (*ipNetSliceValue)(nil)
+var _ Cloner = (*ipNetSliceValue)(nil)
+var _ Resetter = (*ipNetSliceValue)(nil)
 
 func newIPNetSliceValue(val []net.IPNet, p *[]net.IPNet) *ipNetSliceValue {
 	ipnsv := new(ipNetSliceValue)
 	ipnsv.value = p
 	*ipnsv.value = val
+	ipnsv.defaults = val
 	return ipnsv
 }
 
@@ -59,6 +63,16 @@ func (s *ipNetSliceValue) Type() string {
 	return "ipNetSlice"
 }
 
+func (s *ipNetSliceValue) Clone() Value {
+	value := append([]net.IPNet(nil), *s.value...)
+	return &ipNetSliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *ipNetSliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 // String defines a "native" format for this net.IPNet slice flag value.
 func (s *ipNetSliceValue) String() string {
 	if s.value == nil {
@@ -0,0 +1,60 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// OccurrenceForm describes how a flag was passed on the command line. The
+// forms are combined, e.g. FormLong|FormInlineValue for --flag=value.
+type OccurrenceForm uint8
+
+const (
+	// FormLong is set when the flag was passed by its name, an alias or an
+	// abbreviation, e.g. --flag.
+	FormLong OccurrenceForm = 1 << iota
+	// FormShorthand is set when the flag was passed by a shorthand, e.g. -f.
+	FormShorthand
+	// FormNegated is set when a boolean flag was passed with the no- prefix, e.g. --no-flag.
+	FormNegated
+	// FormInlineValue is set when the value was part of the argument, e.g.
+	// --flag=value, -f=value or -fvalue.
+	FormInlineValue
+	// FormSeparateValue is set when the value was the next argument, e.g. --flag value.
+	FormSeparateValue
+)
+
+// Has returns whether all of the forms in form are set.
+func (f OccurrenceForm) Has(form OccurrenceForm) bool {
+	return f&form == form
+}
+
+// Occurrence is an appearance of a flag on the command line.
+type Occurrence struct {
+	Token string         // Token is the argument containing the flag, e.g. --flag=value or -vf.
+	Index int            // Index is the position of Token in the parsed arguments.
+	Name  string         // Name is the name used, without dashes, e.g. an alias, abbreviation or shorthand.
+	Form  OccurrenceForm // Form describes how the flag was passed.
+	Value string         // Value is the value passed to the flag, which may be implied, e.g. "false" for --no-flag.
+}
+
+// Occurrences returns every appearance of the flag on the command line, in
+// the order they were parsed.
+func (f *Flag) Occurrences() []Occurrence {
+	return f.occurrences
+}
+
+// addOccurrence records an appearance of the flag in the argument being parsed.
+// When tokenizing, the flag is left untouched.
+func (fs *FlagSet) addOccurrence(flag *Flag, name string, form OccurrenceForm, value string) {
+	occurrence := Occurrence{
+		Token: fs.argToken,
+		Index: fs.argIndex,
+		Name:  name,
+		Form:  form,
+		Value: value,
+	}
+	if fs.tokenizing {
+		fs.pendingOccurrences = append(fs.pendingOccurrences, pendingOccurrence{flag: flag, occurrence: occurrence})
+		return
+	}
+	flag.occurrences = append(flag.occurrences, occurrence)
+}
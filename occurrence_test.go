@@ -0,0 +1,83 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestOccurrences(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		flag     string
+		expected []zflag.Occurrence
+	}{
+		{
+			name: "not passed",
+			args: []string{"--name=a"},
+			flag: "verbose",
+		},
+		{
+			name: "long forms",
+			args: []string{"--name=a", "arg", "--name", "b", "--nickname", "c", "--na=d"},
+			flag: "name",
+			expected: []zflag.Occurrence{
+				{Token: "--name=a", Index: 0, Name: "name", Form: zflag.FormLong | zflag.FormInlineValue, Value: "a"},
+				{Token: "--name", Index: 2, Name: "name", Form: zflag.FormLong | zflag.FormSeparateValue, Value: "b"},
+				{Token: "--nickname", Index: 4, Name: "nickname", Form: zflag.FormLong | zflag.FormSeparateValue, Value: "c"},
+				{Token: "--na=d", Index: 6, Name: "na", Form: zflag.FormLong | zflag.FormInlineValue, Value: "d"},
+			},
+		},
+		{
+			name: "shorthand forms",
+			args: []string{"-n=a", "-vnb", "-n", "c"},
+			flag: "name",
+			expected: []zflag.Occurrence{
+				{Token: "-n=a", Index: 0, Name: "n", Form: zflag.FormShorthand | zflag.FormInlineValue, Value: "a"},
+				{Token: "-vnb", Index: 1, Name: "n", Form: zflag.FormShorthand | zflag.FormInlineValue, Value: "b"},
+				{Token: "-n", Index: 2, Name: "n", Form: zflag.FormShorthand | zflag.FormSeparateValue, Value: "c"},
+			},
+		},
+		{
+			name: "bool forms",
+			args: []string{"--verbose", "-v", "--no-verbose", "--verbose=true"},
+			flag: "verbose",
+			expected: []zflag.Occurrence{
+				{Token: "--verbose", Index: 0, Name: "verbose", Form: zflag.FormLong, Value: "true"},
+				{Token: "-v", Index: 1, Name: "v", Form: zflag.FormShorthand, Value: ""},
+				{Token: "--no-verbose", Index: 2, Name: "verbose", Form: zflag.FormLong | zflag.FormNegated, Value: "false"},
+				{Token: "--verbose=true", Index: 3, Name: "verbose", Form: zflag.FormLong | zflag.FormInlineValue, Value: "true"},
+			},
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.AllowAbbreviations = true
+			f.Bool("verbose", false, "usage", zflag.OptShorthand('v'), zflag.OptAddNegative())
+			f.String("name", "", "usage", zflag.OptShorthand('n'), zflag.OptAlias("nickname"))
+
+			assertNoErr(t, f.Parse(test.args))
+			assertDeepEqual(t, test.expected, f.Lookup(test.flag).Occurrences())
+		})
+	}
+}
+
+func TestOccurrenceForm(t *testing.T) {
+	form := zflag.FormLong | zflag.FormSeparateValue
+	assertEqual(t, true, form.Has(zflag.FormLong))
+	assertEqual(t, true, form.Has(zflag.FormLong|zflag.FormSeparateValue))
+	assertEqual(t, false, form.Has(zflag.FormLong|zflag.FormInlineValue))
+	assertEqual(t, false, form.Has(zflag.FormShorthand))
+}
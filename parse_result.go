@@ -0,0 +1,71 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// ParseResult holds the flags and arguments of a call to ParseToResult.
+type ParseResult struct {
+	fs *FlagSet
+}
+
+// ParseToResult parses the arguments like Parse, but sets the values on a copy
+// of the flags, which is returned as a ParseResult. The FlagSet itself is not
+// changed, so it can be used as a template to parse several argument lists,
+// also concurrently. The copy starts with the current values of the flags, so
+// the template is usually not parsed itself. See Clone for the values which can
+// be copied. The result is also returned when parsing fails.
+func (fs *FlagSet) ParseToResult(arguments []string) (*ParseResult, error) {
+	clone, err := fs.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ParseResult{fs: clone}
+	return result, clone.Parse(arguments)
+}
+
+// ParseToResult parses the command-line flags from the arguments into a ParseResult.
+func ParseToResult(arguments []string) (*ParseResult, error) {
+	return CommandLine.ParseToResult(arguments)
+}
+
+// FlagSet returns the copy of the flags holding the parsed values, which can
+// be used to get the values, e.g. with GetInt. It is not shared with the
+// template or with other results.
+func (r *ParseResult) FlagSet() *FlagSet {
+	return r.fs
+}
+
+// Lookup returns the Flag structure of the named flag, holding its parsed
+// value, returning nil if none exists.
+func (r *ParseResult) Lookup(name string) *Flag {
+	return r.fs.Lookup(name)
+}
+
+// Changed returns true if the named flag was explicitly set.
+func (r *ParseResult) Changed(name string) bool {
+	return r.fs.Changed(name)
+}
+
+// Visit visits the flags which were set, in lexicographical order or in
+// primordial order if SortFlags of the template is false.
+func (r *ParseResult) Visit(fn func(*Flag)) {
+	r.fs.Visit(fn)
+}
+
+// Args returns the non-flag arguments.
+func (r *ParseResult) Args() []string {
+	return r.fs.Args()
+}
+
+// ArgsLenAtDash returns the length of Args at the moment when a -- was found
+// during argument parsing, or -1 if no -- was found.
+func (r *ParseResult) ArgsLenAtDash() int {
+	return r.fs.ArgsLenAtDash()
+}
+
+// GetUnknownFlags returns the unknown flags in the order they were parsed,
+// see ParseErrorsAllowList.UnknownFlags.
+func (r *ParseResult) GetUnknownFlags() []string {
+	return r.fs.GetUnknownFlags()
+}
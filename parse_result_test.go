@@ -0,0 +1,107 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestParseToResult(t *testing.T) {
+	var name string
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StringVar(&name, "name", "default", "usage", zflag.OptShorthand('n'), zflag.OptAlias("nick"))
+	f.Int("count", 1, "usage")
+	f.StringSlice("tags", []string{"a"}, "usage")
+	f.Bool("json", false, "usage")
+	f.Bool("yaml", false, "usage")
+	f.MarkMutuallyExclusive("json", "yaml")
+	f.RequiredIf(zflag.FlagIsSet("json"), "count")
+
+	first, err := f.ParseToResult([]string{"--nick=first", "--tags=b", "arg", "--", "--count=3"})
+	assertNoErr(t, err)
+	second, err := f.ParseToResult([]string{"-n", "second", "--count=2", "--json", "--unknown"})
+	assertErrMsg(t, "unknown flag: --unknown", err)
+	third, err := f.ParseToResult([]string{"--json", "--yaml", "--count=5"})
+	assertErrMsg(t, `flag(s) "--json", "--yaml" are mutually exclusive, but "--json", "--yaml" were set`, err)
+
+	firstName, err := first.FlagSet().GetString("name")
+	assertNoErr(t, err)
+	assertEqual(t, "first", firstName)
+	assertEqual(t, true, first.Changed("name"))
+	assertEqual(t, false, first.Changed("count"))
+	firstTags, err := first.FlagSet().GetStringSlice("tags")
+	assertNoErr(t, err)
+	assertDeepEqual(t, []string{"b"}, firstTags)
+	assertDeepEqual(t, []string{"arg", "--count=3"}, first.Args())
+	assertEqual(t, 1, first.ArgsLenAtDash())
+
+	assertEqual(t, "second", second.Lookup("name").Value.String())
+	assertEqual(t, true, second.Changed("count"))
+	assertEqual(t, -1, second.ArgsLenAtDash())
+
+	var visited []string
+	third.Visit(func(flag *zflag.Flag) {
+		visited = append(visited, flag.Name)
+	})
+	assertDeepEqual(t, []string{"count", "json", "yaml"}, visited)
+
+	assertEqual(t, "default", name)
+	assertEqual(t, false, f.Parsed())
+	assertEqual(t, false, f.Changed("name"))
+	assertEqual(t, "[a]", f.Lookup("tags").Value.String())
+	assertEqual(t, 0, len(f.Args()))
+}
+
+func TestParseToResultUnknownFlags(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("name", "default", "usage")
+	f.ParseErrorsAllowList.UnknownFlags = true
+
+	result, err := f.ParseToResult([]string{"--unknown", "--name=a"})
+	assertNoErr(t, err)
+	assertDeepEqual(t, []string{"--unknown"}, result.GetUnknownFlags())
+	assertEqual(t, 0, len(f.GetUnknownFlags()))
+}
+
+func TestParseToResultConcurrently(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Int("count", 1, "usage")
+	f.StringSlice("tags", []string{"a"}, "usage")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := f.ParseToResult([]string{fmt.Sprintf("--count=%d", i), fmt.Sprintf("--tags=%d", i)})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if count, _ := result.FlagSet().GetInt("count"); count != i {
+				t.Errorf("expected count %d, got %d", i, count)
+			}
+			if tags, _ := result.FlagSet().GetStringSlice("tags"); len(tags) != 1 || tags[0] != fmt.Sprint(i) {
+				t.Errorf("expected tags [%d], got %v", i, tags)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestParseToResultNotCloner(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Var(new(customValue), "custom", "usage")
+
+	_, err := f.ParseToResult([]string{})
+	assertErrMsg(t, `unable to clone flag "custom": value of type *zflag_test.customValue does not implement Cloner`, err)
+}
@@ -0,0 +1,189 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Source identifies where the value of a flag was set from.
+type Source string
+
+const (
+	// SourceDefault is the source of flags which still hold their default value.
+	SourceDefault Source = "default"
+	// SourceConfig is the source of values read with LoadConfig.
+	SourceConfig Source = "config"
+	// SourceEnv is the source of values read from environment variables.
+	SourceEnv Source = "env"
+	// SourceCommandLine is the source of values parsed from the arguments.
+	SourceCommandLine Source = "command-line"
+	// SourceSet is the source of values set with FlagSet.Set.
+	SourceSet Source = "set"
+)
+
+// defaultSourcePrecedence is the order of precedence of the sources, from lowest to highest.
+// SourceSet is not ranked by default, see SetSourcePrecedence.
+var defaultSourcePrecedence = []Source{SourceDefault, SourceConfig, SourceEnv, SourceCommandLine}
+
+// Provider supplies flag values from a source other than the command line.
+// Providers are consulted for every flag after the arguments have been parsed,
+// and their values are only used if their source has the same or a higher
+// precedence than the source of the current value of the flag.
+type Provider interface {
+	// Source returns the source the values are provided from.
+	Source() Source
+	// Lookup returns the values for the flag, and whether a value was found.
+	// Multiple values replace the value of slice flags, and are set one by
+	// one for other flags.
+	Lookup(flag *Flag) ([]string, bool)
+}
+
+// AddProvider adds a provider which is consulted for the value of every flag
+// when parsing. Values from environment variables are always provided, see
+// OptEnv and SetEnvPrefix.
+func (fs *FlagSet) AddProvider(p Provider) {
+	fs.checkNotFrozen("add a provider")
+	fs.providers = append(fs.providers, p)
+}
+
+// AddProvider adds a provider which is consulted for the value of every command-line flag.
+func AddProvider(p Provider) {
+	CommandLine.AddProvider(p)
+}
+
+// SetSourcePrecedence sets the order of precedence of the sources, from lowest
+// to highest. A value is only set when its source has the same or a higher
+// precedence than the source of the current value of the flag. Ignored values
+// from the command line are recorded as a SourcePrecedenceError, see
+// GetIgnoredErrors, and Set returns one. Sources which are not listed have the
+// same precedence as SourceDefault, except for SourceSet: unless it is listed,
+// Set always replaces the current value, and a value from Set is replaced by
+// any value set after it.
+//
+// The default order is SourceDefault, SourceConfig, SourceEnv and
+// SourceCommandLine.
+func (fs *FlagSet) SetSourcePrecedence(sources ...Source) {
+	fs.checkNotFrozen("set the source precedence")
+	fs.sourcePrecedence = sources
+}
+
+// SetSourcePrecedence sets the order of precedence of the sources of command-line flags.
+func SetSourcePrecedence(sources ...Source) {
+	CommandLine.SetSourcePrecedence(sources...)
+}
+
+// sourceRank returns the precedence of the source, and whether the source is
+// listed in the order of precedence.
+func (fs *FlagSet) sourceRank(source Source) (int, bool) {
+	precedence := fs.sourcePrecedence
+	if precedence == nil {
+		precedence = defaultSourcePrecedence
+	}
+
+	for i, s := range precedence {
+		if s == source {
+			return i, true
+		}
+	}
+
+	for i, s := range precedence {
+		if s == SourceDefault {
+			return i, false
+		}
+	}
+	return -1, false
+}
+
+// overrides reports whether a value from source may replace the current value of the flag.
+func (fs *FlagSet) overrides(source Source, flag *Flag) bool {
+	current := flag.Source()
+	if source == SourceSet || current == SourceSet {
+		if _, ranked := fs.sourceRank(SourceSet); !ranked {
+			return true
+		}
+	}
+
+	rank, _ := fs.sourceRank(source)
+	currentRank, _ := fs.sourceRank(current)
+	return rank >= currentRank
+}
+
+// Source returns where the current value of the flag was set from.
+func (f *Flag) Source() Source {
+	if f.source == "" {
+		return SourceDefault
+	}
+	return f.source
+}
+
+// parseProviders sets the flags from the values of all providers.
+func (fs *FlagSet) parseProviders() error {
+	providers := append([]Provider{envProvider{}}, fs.providers...)
+	for _, p := range providers {
+		source := p.Source()
+		for _, flag := range fs.GetAllFlags() {
+			if !fs.overrides(source, flag) {
+				continue
+			}
+
+			values, ok := p.Lookup(flag)
+			if !ok {
+				continue
+			}
+
+			if err := fs.setFromSource(flag, values, source); err != nil {
+				if _, isEnv := p.(envProvider); isEnv {
+					return fmt.Errorf("environment variable %s: %w", flag.EnvVar, err)
+				}
+				return fmt.Errorf("%s: %w", source, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// setFromSource sets the values of a flag, without marking the flag as changed.
+// Slice flags are replaced with the values, and so are map flags, unless their
+// value is from the same source, e.g. for the keys of a map in a config.
+func (fs *FlagSet) setFromSource(flag *Flag, values []string, source Source) error {
+	unlock := fs.rlock()
+	err := fs.checkSettable(flag)
+	merge := flag.source == source
+	unlock()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := flag.Value.(mapValue); ok {
+		err := fs.setValue(flag, func(v Value) error {
+			return v.(mapValue).replace(values, merge)
+		})
+		if err != nil {
+			return NewInvalidArgumentError(err, flag, strings.Join(values, ","))
+		}
+	} else if _, ok := flag.Value.(SliceValue); ok {
+		err := fs.setValue(flag, func(v Value) error {
+			return v.(SliceValue).Replace(values)
+		})
+		if err != nil {
+			return NewInvalidArgumentError(err, flag, strings.Join(values, ","))
+		}
+	} else {
+		for _, value := range values {
+			err := fs.setValue(flag, func(v Value) error {
+				return v.Set(value)
+			})
+			if err != nil {
+				return NewInvalidArgumentError(err, flag, value)
+			}
+		}
+	}
+
+	defer fs.lock()()
+	flag.source = source
+	return nil
+}
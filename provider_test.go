@@ -0,0 +1,184 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+type mapProvider struct {
+	source zflag.Source
+	values map[string][]string
+}
+
+func (p mapProvider) Source() zflag.Source {
+	return p.source
+}
+
+func (p mapProvider) Lookup(flag *zflag.Flag) ([]string, bool) {
+	v, ok := p.values[flag.Name]
+	return v, ok
+}
+
+func TestSource(t *testing.T) {
+	os.Setenv("ZFLAG_TEST_SOURCE_ENV", "env")
+	defer os.Unsetenv("ZFLAG_TEST_SOURCE_ENV")
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("default", "", "usage")
+	f.String("config", "", "usage")
+	f.String("env", "", "usage", zflag.OptEnv("ZFLAG_TEST_SOURCE_ENV"))
+	f.String("cli", "", "usage", zflag.OptEnv("ZFLAG_TEST_SOURCE_ENV"))
+	f.String("set", "", "usage")
+
+	assertNoErr(t, f.LoadConfig(strings.NewReader(`{"config": "config", "env": "config", "cli": "config"}`), zflag.ConfigJSON))
+	assertNoErr(t, f.Parse([]string{"--cli=cli"}))
+	assertNoErr(t, f.Set("set", "set"))
+
+	for name, expected := range map[string]zflag.Source{
+		"default": zflag.SourceDefault,
+		"config":  zflag.SourceConfig,
+		"env":     zflag.SourceEnv,
+		"cli":     zflag.SourceCommandLine,
+		"set":     zflag.SourceSet,
+	} {
+		assertEqual(t, expected, f.Lookup(name).Source())
+	}
+	assertEqual(t, "env", f.Lookup("env").Value.String())
+	assertEqual(t, "cli", f.Lookup("cli").Value.String())
+}
+
+func TestProvider(t *testing.T) {
+	tests := []struct {
+		name           string
+		precedence     []zflag.Source
+		args           []string
+		expectedValue  string
+		expectedSource zflag.Source
+	}{
+		{
+			name:           "provider value",
+			expectedValue:  "remote",
+			expectedSource: "remote",
+		},
+		{
+			name:           "command line wins over unlisted source",
+			args:           []string{"--name=cli"},
+			expectedValue:  "cli",
+			expectedSource: zflag.SourceCommandLine,
+		},
+		{
+			name:           "provider above command line",
+			precedence:     []zflag.Source{zflag.SourceDefault, zflag.SourceCommandLine, "remote"},
+			args:           []string{"--name=cli"},
+			expectedValue:  "remote",
+			expectedSource: "remote",
+		},
+		{
+			name:           "command line above provider",
+			precedence:     []zflag.Source{zflag.SourceDefault, "remote", zflag.SourceCommandLine},
+			args:           []string{"--name=cli"},
+			expectedValue:  "cli",
+			expectedSource: zflag.SourceCommandLine,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			name := f.String("name", "default", "usage")
+			f.AddProvider(mapProvider{source: "remote", values: map[string][]string{"name": {"remote"}}})
+			if test.precedence != nil {
+				f.SetSourcePrecedence(test.precedence...)
+			}
+
+			assertNoErr(t, f.Parse(test.args))
+			assertEqual(t, test.expectedValue, *name)
+			assertEqual(t, test.expectedSource, f.Lookup("name").Source())
+		})
+	}
+}
+
+func TestProviderSlice(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	ss := f.StringSlice("ss", []string{"default"}, "usage")
+	f.AddProvider(mapProvider{source: "remote", values: map[string][]string{"ss": {"a", "b"}}})
+	assertNoErr(t, f.Parse([]string{}))
+	assertDeepEqual(t, []string{"a", "b"}, *ss)
+	assertEqual(t, false, f.Changed("ss"))
+}
+
+func TestProviderError(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Int("port", 0, "usage")
+	f.AddProvider(mapProvider{source: "remote", values: map[string][]string{"port": {"abc"}}})
+	err := f.Parse([]string{})
+	assertErrMsg(t, `remote: invalid argument "abc" for "--port" flag: strconv.ParseInt: parsing "abc": invalid syntax`, err)
+}
+
+func TestSetSourceUnranked(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	name := f.String("name", "default", "usage")
+
+	assertNoErr(t, f.Set("name", "programmatic"))
+	assertNoErr(t, f.Parse([]string{"--name=cli"}))
+	assertEqual(t, "cli", *name)
+	assertEqual(t, zflag.SourceCommandLine, f.Lookup("name").Source())
+
+	assertNoErr(t, f.Set("name", "programmatic"))
+	assertEqual(t, "programmatic", *name)
+	assertEqual(t, zflag.SourceSet, f.Lookup("name").Source())
+	assertEqual(t, 0, len(f.GetIgnoredErrors()))
+}
+
+func TestSetSourceRanked(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetSourcePrecedence(zflag.SourceDefault, zflag.SourceSet, zflag.SourceCommandLine)
+	name := f.String("name", "default", "usage")
+
+	assertNoErr(t, f.Parse([]string{"--name=cli"}))
+	err := f.Set("name", "programmatic")
+	assertErrMsg(t, "value of flag --name from set ignored: the value from command-line has a higher precedence", err)
+	assertEqual(t, "cli", *name)
+}
+
+func TestSourcePrecedenceIgnoredCommandLine(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetSourcePrecedence(zflag.SourceDefault, zflag.SourceCommandLine, zflag.SourceConfig)
+	name := f.String("name", "default", "usage")
+	assertNoErr(t, f.LoadConfig(strings.NewReader("name: config"), zflag.ConfigYAML))
+
+	assertNoErr(t, f.Parse([]string{"--name=cli"}))
+	assertEqual(t, "config", *name)
+	assertEqual(t, false, f.Changed("name"))
+	assertDeepEqual(t, []error{zflag.SourcePrecedenceError{
+		Flag:    "--name",
+		Source:  zflag.SourceCommandLine,
+		Current: zflag.SourceConfig,
+	}}, f.GetIgnoredErrors())
+}
+
+func TestSetSourcePrecedenceConfigOverEnv(t *testing.T) {
+	os.Setenv("ZFLAG_TEST_SOURCE_PRECEDENCE", "env")
+	defer os.Unsetenv("ZFLAG_TEST_SOURCE_PRECEDENCE")
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetSourcePrecedence(zflag.SourceDefault, zflag.SourceEnv, zflag.SourceConfig, zflag.SourceCommandLine, zflag.SourceSet)
+	name := f.String("name", "", "usage", zflag.OptEnv("ZFLAG_TEST_SOURCE_PRECEDENCE"))
+	assertNoErr(t, f.LoadConfig(strings.NewReader("name: config"), zflag.ConfigYAML))
+	assertNoErr(t, f.Parse([]string{}))
+	assertEqual(t, "config", *name)
+	assertEqual(t, zflag.SourceConfig, f.Lookup("name").Source())
+}
@@ -0,0 +1,171 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestReset(t *testing.T) {
+	tests := []struct {
+		name   string
+		define func(f *zflag.FlagSet)
+		arg    string
+	}{
+		{name: "bool", define: func(f *zflag.FlagSet) { f.Bool("flag", true, "usage") }, arg: "--flag=false"},
+		{name: "bool slice", define: func(f *zflag.FlagSet) { f.BoolSlice("flag", []bool{true}, "usage") }, arg: "--flag=false"},
+		{name: "bytes hex", define: func(f *zflag.FlagSet) { f.BytesHex("flag", []byte{1}, "usage") }, arg: "--flag=02"},
+		{name: "bytes hex nil", define: func(f *zflag.FlagSet) { f.BytesHex("flag", nil, "usage") }, arg: "--flag=02"},
+		{name: "bytes base64", define: func(f *zflag.FlagSet) { f.BytesBase64("flag", []byte{1}, "usage") }, arg: "--flag=Ag=="},
+		{name: "bytes base64 nil", define: func(f *zflag.FlagSet) { f.BytesBase64("flag", nil, "usage") }, arg: "--flag=Ag=="},
+		{name: "complex128", define: func(f *zflag.FlagSet) { f.Complex128("flag", 1+2i, "usage") }, arg: "--flag=3+4i"},
+		{name: "complex128 slice", define: func(f *zflag.FlagSet) { f.Complex128Slice("flag", []complex128{1 + 2i}, "usage") }, arg: "--flag=3+4i"},
+		{name: "count", define: func(f *zflag.FlagSet) { f.Count("flag", "usage") }, arg: "--flag"},
+		{name: "duration", define: func(f *zflag.FlagSet) { f.Duration("flag", time.Second, "usage") }, arg: "--flag=2s"},
+		{name: "duration slice", define: func(f *zflag.FlagSet) { f.DurationSlice("flag", []time.Duration{time.Second}, "usage") }, arg: "--flag=2s"},
+		{name: "enum", define: func(f *zflag.FlagSet) { f.Enum("flag", "a", []string{"a", "b"}, "usage") }, arg: "--flag=b"},
+		{name: "enum empty", define: func(f *zflag.FlagSet) { f.Enum("flag", "", []string{"a", "b"}, "usage") }, arg: "--flag=b"},
+		{name: "float32", define: func(f *zflag.FlagSet) { f.Float32("flag", 1.5, "usage") }, arg: "--flag=2.5"},
+		{name: "float32 slice", define: func(f *zflag.FlagSet) { f.Float32Slice("flag", []float32{1.5}, "usage") }, arg: "--flag=2.5"},
+		{name: "float64", define: func(f *zflag.FlagSet) { f.Float64("flag", 1.5, "usage") }, arg: "--flag=2.5"},
+		{name: "float64 slice", define: func(f *zflag.FlagSet) { f.Float64Slice("flag", []float64{1.5}, "usage") }, arg: "--flag=2.5"},
+		{name: "int", define: func(f *zflag.FlagSet) { f.Int("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "int slice", define: func(f *zflag.FlagSet) { f.IntSlice("flag", []int{1}, "usage") }, arg: "--flag=2"},
+		{name: "int8", define: func(f *zflag.FlagSet) { f.Int8("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "int8 slice", define: func(f *zflag.FlagSet) { f.Int8Slice("flag", []int8{1}, "usage") }, arg: "--flag=2"},
+		{name: "int16", define: func(f *zflag.FlagSet) { f.Int16("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "int16 slice", define: func(f *zflag.FlagSet) { f.Int16Slice("flag", []int16{1}, "usage") }, arg: "--flag=2"},
+		{name: "int32", define: func(f *zflag.FlagSet) { f.Int32("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "int32 slice", define: func(f *zflag.FlagSet) { f.Int32Slice("flag", []int32{1}, "usage") }, arg: "--flag=2"},
+		{name: "int64", define: func(f *zflag.FlagSet) { f.Int64("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "int64 slice", define: func(f *zflag.FlagSet) { f.Int64Slice("flag", []int64{1}, "usage") }, arg: "--flag=2"},
+		{name: "ip", define: func(f *zflag.FlagSet) { f.IP("flag", net.IPv4(127, 0, 0, 1), "usage") }, arg: "--flag=10.0.0.1"},
+		{name: "ip nil", define: func(f *zflag.FlagSet) { f.IP("flag", nil, "usage") }, arg: "--flag=1.2.3.4"},
+		{name: "ip slice", define: func(f *zflag.FlagSet) { f.IPSlice("flag", []net.IP{net.IPv4(127, 0, 0, 1)}, "usage") }, arg: "--flag=10.0.0.1"},
+		{name: "ip mask", define: func(f *zflag.FlagSet) { f.IPMask("flag", net.IPv4Mask(255, 255, 255, 0), "usage") }, arg: "--flag=255.255.0.0"},
+		{name: "ip mask nil", define: func(f *zflag.FlagSet) { f.IPMask("flag", nil, "usage") }, arg: "--flag=255.255.0.0"},
+		{name: "ip net", define: func(f *zflag.FlagSet) {
+			f.IPNet("flag", net.IPNet{IP: net.IPv4(10, 0, 0, 0), Mask: net.IPv4Mask(255, 0, 0, 0)}, "usage")
+		}, arg: "--flag=192.168.0.0/16"},
+		{name: "ip net zero", define: func(f *zflag.FlagSet) { f.IPNet("flag", net.IPNet{}, "usage") }, arg: "--flag=192.168.0.0/16"},
+		{name: "ip net slice", define: func(f *zflag.FlagSet) {
+			f.IPNetSlice("flag", []net.IPNet{{IP: net.IPv4(10, 0, 0, 0), Mask: net.IPv4Mask(255, 0, 0, 0)}}, "usage")
+		}, arg: "--flag=192.168.0.0/16"},
+		{name: "string", define: func(f *zflag.FlagSet) { f.String("flag", "a", "usage") }, arg: "--flag=b"},
+		{name: "string slice", define: func(f *zflag.FlagSet) { f.StringSlice("flag", []string{"a"}, "usage") }, arg: "--flag=b"},
+		{name: "string to int", define: func(f *zflag.FlagSet) { f.StringToInt("flag", map[string]int{"a": 1}, "usage") }, arg: "--flag=b=2"},
+		{name: "string to int64", define: func(f *zflag.FlagSet) { f.StringToInt64("flag", map[string]int64{"a": 1}, "usage") }, arg: "--flag=b=2"},
+		{name: "string to string", define: func(f *zflag.FlagSet) { f.StringToString("flag", map[string]string{"a": "b"}, "usage") }, arg: "--flag=b=c"},
+		{name: "time", define: func(f *zflag.FlagSet) {
+			f.Time("flag", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), []string{time.RFC3339}, "usage")
+		}, arg: "--flag=2021-01-02T03:04:05Z"},
+		{name: "uint", define: func(f *zflag.FlagSet) { f.Uint("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "uint slice", define: func(f *zflag.FlagSet) { f.UintSlice("flag", []uint{1}, "usage") }, arg: "--flag=2"},
+		{name: "uint8", define: func(f *zflag.FlagSet) { f.Uint8("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "uint8 slice", define: func(f *zflag.FlagSet) { f.Uint8Slice("flag", []uint8{1}, "usage") }, arg: "--flag=2"},
+		{name: "uint16", define: func(f *zflag.FlagSet) { f.Uint16("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "uint16 slice", define: func(f *zflag.FlagSet) { f.Uint16Slice("flag", []uint16{1}, "usage") }, arg: "--flag=2"},
+		{name: "uint32", define: func(f *zflag.FlagSet) { f.Uint32("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "uint32 slice", define: func(f *zflag.FlagSet) { f.Uint32Slice("flag", []uint32{1}, "usage") }, arg: "--flag=2"},
+		{name: "uint64", define: func(f *zflag.FlagSet) { f.Uint64("flag", 1, "usage") }, arg: "--flag=2"},
+		{name: "uint64 slice", define: func(f *zflag.FlagSet) { f.Uint64Slice("flag", []uint64{1}, "usage") }, arg: "--flag=2"},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			test.define(f)
+			flag := f.Lookup("flag")
+
+			assertNoErr(t, f.Parse([]string{test.arg}))
+			parsed := flag.Value.String()
+			if parsed == flag.DefValue {
+				t.Fatalf("expected %q to change the value of the flag", test.arg)
+			}
+
+			assertNoErr(t, f.Reset())
+			assertEqual(t, flag.DefValue, flag.Value.String())
+			assertEqual(t, false, flag.Changed)
+			assertEqual(t, zflag.SourceDefault, flag.Source())
+			assertEqual(t, 0, len(flag.Occurrences()))
+
+			assertNoErr(t, f.Parse([]string{test.arg}))
+			assertEqual(t, parsed, flag.Value.String())
+		})
+	}
+}
+
+func TestResetParseState(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.ParseErrorsAllowList.UnknownFlags = true
+	f.Int("count", 1, "usage")
+	args := []string{"--count=2", "--unknown=value", "arg", "--", "rest"}
+
+	assertNoErr(t, f.Parse(args))
+	assertNoErr(t, f.Reset())
+	assertEqual(t, false, f.Parsed())
+	assertEqual(t, 0, f.NFlag())
+	assertEqual(t, 0, len(f.Args()))
+	assertEqual(t, -1, f.ArgsLenAtDash())
+	assertEqual(t, 0, len(f.GetUnknownFlags()))
+
+	assertNoErr(t, f.Parse(args))
+	assertEqual(t, 1, f.NFlag())
+	assertDeepEqual(t, []string{"arg", "rest"}, f.Args())
+	assertEqual(t, 1, f.ArgsLenAtDash())
+	assertDeepEqual(t, []string{"--unknown=value"}, f.GetUnknownFlags())
+}
+
+func TestResetNilDefaultPointers(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	ip := f.IP("ip", nil, "usage")
+	mask := f.IPMask("ip-mask", nil, "usage")
+	ipNet := f.IPNet("ip-net", net.IPNet{}, "usage")
+
+	assertNoErr(t, f.Parse([]string{"--ip=1.2.3.4", "--ip-mask=255.255.0.0", "--ip-net=10.0.0.0/8"}))
+	assertNoErr(t, f.Reset())
+	assertEqual(t, true, *ip == nil)
+	assertEqual(t, true, *mask == nil)
+	assertDeepEqual(t, net.IPNet{}, *ipNet)
+}
+
+func TestResetSlicesStartOver(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	tags := f.StringSlice("tags", []string{"default"}, "usage")
+
+	assertNoErr(t, f.Parse([]string{"--tags=a", "--tags=b"}))
+	assertDeepEqual(t, []string{"a", "b"}, *tags)
+	assertNoErr(t, f.Reset())
+	assertDeepEqual(t, []string{"default"}, *tags)
+	assertNoErr(t, f.Parse([]string{"--tags=c"}))
+	assertDeepEqual(t, []string{"c"}, *tags)
+}
+
+type notResettableValue struct{}
+
+func (v *notResettableValue) String() string     { return "" }
+func (v *notResettableValue) Set(s string) error { return errors.New("not resettable") }
+
+func TestResetError(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Var(new(notResettableValue), "custom", "usage")
+	count := f.Int("count", 1, "usage")
+	assertNoErr(t, f.Set("count", "2"))
+
+	assertErrMsg(t, `unable to reset flag "custom": not resettable`, f.Reset())
+	assertEqual(t, 1, *count)
+	assertEqual(t, false, f.Changed("count"))
+}
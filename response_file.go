@@ -0,0 +1,143 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// isResponseFile reports whether the argument refers to a response file.
+func isResponseFile(arg string) bool {
+	return len(arg) > 1 && arg[0] == '@' && arg[1] != '@'
+}
+
+// expandResponseFileArg replaces the response file argument s, which is being
+// parsed, with the arguments read from the file. The arguments read from the
+// file get the index of s.
+func (fs *FlagSet) expandResponseFileArg(s string, args []string, indexes []int) ([]string, []int, error) {
+	included, _, err := expandResponseFile(s[1:], nil)
+	if err != nil {
+		return nil, nil, fs.failf("response file %s: %w", s[1:], err)
+	}
+
+	includedIndexes := make([]int, len(included), len(included)+len(indexes))
+	for i := range includedIndexes {
+		includedIndexes[i] = fs.argIndex
+	}
+	return append(included, args...), append(includedIndexes, indexes...), nil
+}
+
+// expandResponseFile reads the arguments from the response file name. Response
+// files included by the file are expanded recursively, up to a "--" argument.
+// The stack holds the files currently being expanded, and is used to detect
+// include cycles. The returned bool is set if a "--" argument was found.
+func expandResponseFile(name string, stack []string) ([]string, bool, error) {
+	path, err := filepath.Abs(name)
+	if err != nil {
+		return nil, false, err
+	}
+	for i, p := range stack {
+		if p == path {
+			return nil, false, fmt.Errorf("include cycle: %s", strings.Join(append(stack[i:], path), " -> "))
+		}
+	}
+	stack = append(stack, path)
+
+	content, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	tokens, err := splitResponseFile(string(content))
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", name, err)
+	}
+
+	args := make([]string, 0, len(tokens))
+	terminated := false
+	for _, token := range tokens {
+		switch {
+		case terminated:
+			args = append(args, token)
+		case token == "--":
+			args = append(args, token)
+			terminated = true
+		case isResponseFile(token):
+			var included []string
+			included, terminated, err = expandResponseFile(token[1:], stack)
+			if err != nil {
+				return nil, false, err
+			}
+			args = append(args, included...)
+		default:
+			args = append(args, token)
+		}
+	}
+
+	return args, terminated, nil
+}
+
+// splitResponseFile splits the content of a response file into arguments.
+// Arguments are separated by white space, and may be quoted with single or
+// double quotes. Within double quotes and outside of quotes a backslash escapes
+// the next character. A '#' at the start of an argument starts a comment which
+// runs until the end of the line.
+func splitResponseFile(content string) ([]string, error) {
+	var (
+		args      []string
+		current   strings.Builder
+		inArg     bool
+		inComment bool
+		quote     rune
+		escaped   bool
+	)
+
+	for _, r := range content {
+		switch {
+		case inComment:
+			inComment = r != '\n'
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\r' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		case r == '#' && !inArg:
+			inComment = true
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	switch {
+	case escaped:
+		return nil, errors.New("unexpected end of file after '\\'")
+	case quote != 0:
+		return nil, fmt.Errorf("unterminated quoted argument %c%s", quote, current.String())
+	case inArg:
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
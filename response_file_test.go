@@ -0,0 +1,188 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func writeResponseFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		content = strings.ReplaceAll(content, "{dir}", dir)
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestResponseFiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        map[string]string
+		args         []string
+		disabled     bool
+		expectedErr  string
+		expectedName string
+		expectedTags []string
+		expectedArgs []string
+	}{
+		{
+			name:         "expands file",
+			files:        map[string]string{"args": "--name=file --tags a\n--tags=b arg"},
+			args:         []string{"@{dir}/args", "--tags=c"},
+			expectedName: "file",
+			expectedTags: []string{"a", "b", "c"},
+			expectedArgs: []string{"arg"},
+		},
+		{
+			name:         "disabled by default",
+			files:        map[string]string{"args": "--name=file"},
+			args:         []string{"@{dir}/args"},
+			disabled:     true,
+			expectedName: "default",
+			expectedArgs: []string{"@{dir}/args"},
+		},
+		{
+			name: "quotes and comments",
+			files: map[string]string{"args": strings.Join([]string{
+				"# a comment --name=comment",
+				`--name "my name" # trailing comment`,
+				`--tags 'single \ quoted' --tags "double \"quoted\""`,
+				`--tags escaped\ space --tags=in#side`,
+				`''`,
+			}, "\n")},
+			args:         []string{"@{dir}/args"},
+			expectedName: "my name",
+			expectedTags: []string{`single \ quoted`, `double "quoted"`, "escaped space", "in#side"},
+			expectedArgs: []string{""},
+		},
+		{
+			name: "nested files",
+			files: map[string]string{
+				"args":   "--name=outer @{dir}/nested --tags=c",
+				"nested": "--tags=a --tags=b",
+			},
+			args:         []string{"@{dir}/args"},
+			expectedName: "outer",
+			expectedTags: []string{"a", "b", "c"},
+		},
+		{
+			name:         "double dash stops expansion",
+			files:        map[string]string{"args": "--name=file"},
+			args:         []string{"--", "@{dir}/args"},
+			expectedName: "default",
+			expectedArgs: []string{"@{dir}/args"},
+		},
+		{
+			name: "double dash in file stops expansion",
+			files: map[string]string{
+				"args":   "@{dir}/first @{dir}/second",
+				"first":  "--name=first --",
+				"second": "--name=second",
+			},
+			args:         []string{"@{dir}/args"},
+			expectedName: "first",
+			expectedArgs: []string{"@{dir}/second"},
+		},
+		{
+			name:         "escaped at",
+			args:         []string{"@@literal", "--tags=@@value"},
+			expectedName: "default",
+			expectedTags: []string{"@@value"},
+			expectedArgs: []string{"@literal"},
+		},
+		{
+			name:         "flag values are not expanded",
+			files:        map[string]string{"args": "--name=file"},
+			args:         []string{"--name", "@{dir}/args", "--tags", "@@value", "@@literal"},
+			expectedName: "@{dir}/args",
+			expectedTags: []string{"@@value"},
+			expectedArgs: []string{"@literal"},
+		},
+		{
+			name:        "missing file",
+			args:        []string{"@{dir}/missing"},
+			expectedErr: "response file {dir}/missing: open {dir}/missing: no such file or directory",
+		},
+		{
+			name:        "unterminated quote",
+			files:       map[string]string{"args": `--name "abc`},
+			args:        []string{"@{dir}/args"},
+			expectedErr: `response file {dir}/args: {dir}/args: unterminated quoted argument "abc`,
+		},
+		{
+			name: "include cycle",
+			files: map[string]string{
+				"args":   "@{dir}/nested",
+				"nested": "@{dir}/args",
+			},
+			args:        []string{"@{dir}/args"},
+			expectedErr: "response file {dir}/args: include cycle: {dir}/args -> {dir}/nested -> {dir}/args",
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := writeResponseFiles(t, test.files)
+			replaceDir := func(s string) string {
+				return strings.ReplaceAll(s, "{dir}", dir)
+			}
+			args := make([]string, len(test.args))
+			for i, arg := range test.args {
+				args[i] = replaceDir(arg)
+			}
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.EnableResponseFiles = !test.disabled
+			name := f.String("name", "default", "usage")
+			tags := f.StringSlice("tags", nil, "usage")
+
+			err := f.Parse(args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, replaceDir(test.expectedErr), err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, replaceDir(test.expectedName), *name)
+			if test.expectedTags != nil {
+				assertDeepEqual(t, test.expectedTags, *tags)
+			}
+			expectedArgs := []string{}
+			for _, arg := range test.expectedArgs {
+				expectedArgs = append(expectedArgs, replaceDir(arg))
+			}
+			assertDeepEqual(t, expectedArgs, f.Args())
+		})
+	}
+}
+
+func TestResponseFileErrorIndex(t *testing.T) {
+	dir := writeResponseFiles(t, map[string]string{"args": "--name=file --name"})
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.EnableResponseFiles = true
+	f.String("name", "default", "usage")
+
+	err := f.Parse([]string{"arg", "@" + filepath.Join(dir, "args"), "--name=last"})
+	var needsArgumentErr zflag.FlagNeedsArgumentError
+	if !errors.As(err, &needsArgumentErr) {
+		t.Fatalf("expected a FlagNeedsArgumentError, got %v", err)
+	}
+	assertEqual(t, "--name", needsArgumentErr.Token)
+	assertEqual(t, 1, needsArgumentErr.Index)
+}
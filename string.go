@@ -4,31 +4,45 @@
 package zflag
 
 // -- string Value
-type stringValue string
+type stringValue struct {
+	value    *string
+	defValue string
+}
 
 var _ Value = (*stringValue)(nil)
 var _ Getter = (*stringValue)(nil)
 var _ Typed = (*stringValue)(nil)
+var _ Cloner = (*stringValue)(nil)
+var _ Resetter = (*stringValue)(nil)
 
 func newStringValue(val string, p *string) *stringValue {
 	*p = val
-	return (*stringValue)(p)
+	return &stringValue{value: p, defValue: val}
 }
 
 func (s *stringValue) Set(val string) error {
-	*s = stringValue(val)
+	*s.value = val
 	return nil
 }
 
 func (s *stringValue) Get() interface{} {
-	return string(*s)
+	return *s.value
 }
 
 func (s *stringValue) Type() string {
 	return "string"
 }
 
-func (s *stringValue) String() string { return string(*s) }
+func (s *stringValue) Clone() Value {
+	value := *s.value
+	return &stringValue{value: &value, defValue: s.defValue}
+}
+
+func (s *stringValue) Reset() {
+	*s.value = s.defValue
+}
+
+func (s *stringValue) String() string { return *s.value }
 
 // GetString return the string value of a flag with the given name
 func (fs *FlagSet) GetString(name string) (string, error) {
@@ -9,19 +9,23 @@ import (
 
 // -- stringSlice Value
 type stringSliceValue struct {
-	value   *[]string
-	changed bool
+	value    *[]string
+	changed  bool
+	defaults []string
 }
 
 var _ Value = (*stringSliceValue)(nil)
 var _ Getter = (*stringSliceValue)(nil)
 var _ SliceValue = (*stringSliceValue)(nil)
 var _ Typed = (*stringSliceValue)(nil)
+var _ Cloner = (*stringSliceValue)(nil)
+var _ Resetter = (*stringSliceValue)(nil)
 
 func newStringSliceValue(val []string, p *[]string) *stringSliceValue {
 	ssv := new(stringSliceValue)
 	ssv.value = p
 	*ssv.value = val
+	ssv.defaults = val
 	return ssv
 }
 
@@ -43,6 +47,16 @@ func (s *stringSliceValue) Type() string {
 	return "stringSlice"
 }
 
+func (s *stringSliceValue) Clone() Value {
+	value := append([]string(nil), *s.value...)
+	return &stringSliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *stringSliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *stringSliceValue) String() string {
 	if s.value == nil {
 		return "[]"
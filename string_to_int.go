@@ -14,16 +14,21 @@ type stringToIntValue struct {
 	value         *map[string]int
 	changed       bool
 	valueOptional bool
+	defaults      map[string]int
 }
 
 var _ Value = (*stringToIntValue)(nil)
 var _ Getter = (*stringToIntValue)(nil)
 var _ Typed = (*stringToIntValue)(nil)
+var _ Cloner = (*stringToIntValue)(nil)
+var _ Resetter = (*stringToIntValue)(nil)
+var _ mapValue = (*stringToIntValue)(nil)
 
 func newStringToIntValue(val map[string]int, p *map[string]int) *stringToIntValue {
 	ssv := new(stringToIntValue)
 	ssv.value = p
 	*ssv.value = val
+	ssv.defaults = val
 	return ssv
 }
 
@@ -59,6 +64,38 @@ func (s *stringToIntValue) Type() string {
 	return "stringToInt"
 }
 
+func (s *stringToIntValue) Clone() Value {
+	clone := *s
+	value := make(map[string]int, len(*s.value))
+	for k, v := range *s.value {
+		value[k] = v
+	}
+	clone.value = &value
+	return &clone
+}
+
+func (s *stringToIntValue) replace(entries []string, merge bool) error {
+	value := map[string]int{}
+	if merge {
+		for k, v := range *s.value {
+			value[k] = v
+		}
+	}
+	entriesValue := &stringToIntValue{value: &value, changed: true, valueOptional: s.valueOptional}
+	for _, entry := range entries {
+		if err := entriesValue.Set(entry); err != nil {
+			return err
+		}
+	}
+	*s.value = value
+	return nil
+}
+
+func (s *stringToIntValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *stringToIntValue) String() string {
 	records := make([]string, 0, len(*s.value)>>1)
 	for k, v := range *s.value {
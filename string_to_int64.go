@@ -14,16 +14,21 @@ type stringToInt64Value struct {
 	value         *map[string]int64
 	changed       bool
 	valueOptional bool
+	defaults      map[string]int64
 }
 
 var _ Value = (*stringToInt64Value)(nil)
 var _ Getter = (*stringToInt64Value)(nil)
 var _ Typed = (*stringToInt64Value)(nil)
+var _ Cloner = (*stringToInt64Value)(nil)
+var _ Resetter = (*stringToInt64Value)(nil)
+var _ mapValue = (*stringToInt64Value)(nil)
 
 func newStringToInt64Value(val map[string]int64, p *map[string]int64) *stringToInt64Value {
 	ssv := new(stringToInt64Value)
 	ssv.value = p
 	*ssv.value = val
+	ssv.defaults = val
 	return ssv
 }
 
@@ -59,6 +64,38 @@ func (s *stringToInt64Value) Type() string {
 	return "stringToInt64"
 }
 
+func (s *stringToInt64Value) Clone() Value {
+	clone := *s
+	value := make(map[string]int64, len(*s.value))
+	for k, v := range *s.value {
+		value[k] = v
+	}
+	clone.value = &value
+	return &clone
+}
+
+func (s *stringToInt64Value) replace(entries []string, merge bool) error {
+	value := map[string]int64{}
+	if merge {
+		for k, v := range *s.value {
+			value[k] = v
+		}
+	}
+	entriesValue := &stringToInt64Value{value: &value, changed: true, valueOptional: s.valueOptional}
+	for _, entry := range entries {
+		if err := entriesValue.Set(entry); err != nil {
+			return err
+		}
+	}
+	*s.value = value
+	return nil
+}
+
+func (s *stringToInt64Value) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *stringToInt64Value) String() string {
 	records := make([]string, 0, len(*s.value)>>1)
 	for k, v := range *s.value {
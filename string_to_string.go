@@ -14,16 +14,21 @@ type stringToStringValue struct {
 	value         *map[string]string
 	changed       bool
 	valueOptional bool
+	defaults      map[string]string
 }
 
 var _ Value = (*stringToStringValue)(nil)
 var _ Getter = (*stringToStringValue)(nil)
 var _ Typed = (*stringToStringValue)(nil)
+var _ Cloner = (*stringToStringValue)(nil)
+var _ Resetter = (*stringToStringValue)(nil)
+var _ mapValue = (*stringToStringValue)(nil)
 
 func newStringToStringValue(val map[string]string, p *map[string]string) *stringToStringValue {
 	ssv := new(stringToStringValue)
 	ssv.value = p
 	*ssv.value = val
+	ssv.defaults = val
 	return ssv
 }
 
@@ -57,6 +62,38 @@ func (s *stringToStringValue) Type() string {
 	return "stringToString"
 }
 
+func (s *stringToStringValue) Clone() Value {
+	clone := *s
+	value := make(map[string]string, len(*s.value))
+	for k, v := range *s.value {
+		value[k] = v
+	}
+	clone.value = &value
+	return &clone
+}
+
+func (s *stringToStringValue) replace(entries []string, merge bool) error {
+	value := map[string]string{}
+	if merge {
+		for k, v := range *s.value {
+			value[k] = v
+		}
+	}
+	entriesValue := &stringToStringValue{value: &value, changed: true, valueOptional: s.valueOptional}
+	for _, entry := range entries {
+		if err := entriesValue.Set(entry); err != nil {
+			return err
+		}
+	}
+	*s.value = value
+	return nil
+}
+
+func (s *stringToStringValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *stringToStringValue) String() string {
 	records := make([]string, 0, len(*s.value)>>1)
 	for k, v := range *s.value {
@@ -0,0 +1,119 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// suggestFlags returns the flags, with dashes, whose names or aliases are
+// close to the unknown flag name, or start with it. A single character name
+// is compared to the shorthands instead, ignoring case.
+func (fs *FlagSet) suggestFlags(name string) []string {
+	maxDistance := fs.SuggestionsMaxDistance
+	switch {
+	case maxDistance < 0:
+		return nil
+	case maxDistance == 0:
+		maxDistance = 2
+	}
+
+	var suggestions []suggestion
+	seen := make(map[string]bool)
+	add := func(name string, distance int) {
+		if !seen[name] {
+			seen[name] = true
+			suggestions = append(suggestions, suggestion{name: name, distance: distance})
+		}
+	}
+
+	normalName := strings.ToLower(string(fs.normalizeFlagName(name)))
+	singleChar := utf8.RuneCountInString(name) == 1
+	for _, flag := range fs.orderedFormal {
+		if flag.Hidden {
+			continue
+		}
+
+		if singleChar {
+			for _, r := range flag.visibleShorthands() {
+				if strings.EqualFold(string(r), name) {
+					add("-"+string(r), 0)
+				}
+			}
+			continue
+		}
+
+		if flag.ShorthandOnly {
+			continue
+		}
+		for _, candidate := range append([]string{flag.Name}, flag.Aliases...) {
+			lowerCandidate := strings.ToLower(candidate)
+			distance := levenshteinDistance(normalName, lowerCandidate)
+			if distance <= maxDistance || strings.HasPrefix(lowerCandidate, normalName) {
+				add("--"+candidate, distance)
+			}
+		}
+	}
+
+	return suggestionNames(suggestions)
+}
+
+// suggestion is a flag suggested for an unknown flag.
+type suggestion struct {
+	name     string
+	distance int
+}
+
+// suggestionNames returns the names of the suggestions, closest first.
+func suggestionNames(suggestions []suggestion) []string {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].name < suggestions[j].name
+	})
+
+	var names []string
+	for _, s := range suggestions {
+		names = append(names, s.name)
+	}
+	return names
+}
+
+// levenshteinDistance returns the number of single character insertions,
+// deletions and substitutions needed to turn s into t.
+func levenshteinDistance(s, t string) int {
+	a, b := []rune(s), []rune(t)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
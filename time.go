@@ -12,18 +12,22 @@ import (
 // TimeValue adapts time.Time for use as a flag.
 type TimeValue struct {
 	*time.Time
-	formats []string
+	formats  []string
+	defValue time.Time
 }
 
 var _ Value = (*TimeValue)(nil)
 var _ Getter = (*TimeValue)(nil)
 var _ Typed = (*TimeValue)(nil)
+var _ Cloner = (*TimeValue)(nil)
+var _ Resetter = (*TimeValue)(nil)
 
 func newTimeValue(val time.Time, p *time.Time, formats []string) *TimeValue {
 	*p = val
 	return &TimeValue{
-		Time:    p,
-		formats: formats,
+		Time:     p,
+		formats:  formats,
+		defValue: val,
 	}
 }
 
@@ -52,6 +56,15 @@ func (d *TimeValue) Type() string {
 	return "time"
 }
 
+func (d *TimeValue) Clone() Value {
+	t := *d.Time
+	return &TimeValue{Time: &t, formats: d.formats, defValue: d.defValue}
+}
+
+func (d *TimeValue) Reset() {
+	*d.Time = d.defValue
+}
+
 func (d *TimeValue) String() string { return d.Time.Format(time.RFC3339Nano) }
 
 // GetTime return the time value of a flag with the given name
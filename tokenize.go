@@ -0,0 +1,123 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"io/ioutil"
+)
+
+// TokenKind is the kind of a Token.
+type TokenKind int
+
+const (
+	// TokenFlag is a known flag, with its value.
+	TokenFlag TokenKind = iota
+	// TokenUnknownFlag is an unknown flag, with the value it consumed, if any.
+	TokenUnknownFlag
+	// TokenPositional is a positional argument.
+	TokenPositional
+	// TokenTerminator is the "--" argument terminating the flags.
+	TokenTerminator
+)
+
+// Token is an element of the arguments, as returned by Tokenize.
+type Token struct {
+	Kind  TokenKind      // Kind is the kind of the token.
+	Args  []string       // Args are the arguments of the token, e.g. "--name" and "value" for --name value.
+	Index int            // Index is the position of the first of Args in the arguments.
+	Flag  *Flag          // Flag is the flag of a TokenFlag.
+	Name  string         // Name is the name used for the flag of a TokenFlag, without dashes.
+	Form  OccurrenceForm // Form describes how the flag of a TokenFlag was passed.
+	Value string         // Value is the value passed to the flag of a TokenFlag.
+}
+
+// Tokenize splits the arguments into tokens, using the same grammar as Parse,
+// without setting any flags or otherwise changing the FlagSet. Unknown flags
+// and --help, unless it is defined, are returned as TokenUnknownFlag. Flags
+// combined into one argument, e.g. -vn, each get a token with that argument
+// and the same Index. The values are not checked, and neither the providers
+// nor Validate are run.
+func (fs *FlagSet) Tokenize(args []string) ([]Token, error) {
+	tfs := *fs
+	tfs.tokenizing = true
+	tfs.tokens = nil
+	tfs.pendingOccurrences = nil
+	tfs.args = make([]string, 0, len(args))
+	tfs.unknownFlags = nil
+	tfs.ignoredErrors = nil
+	tfs.output = ioutil.Discard
+	tfs.Usage = func() {}
+	tfs.DisableBuiltinHelp = true
+	tfs.ParseErrorsAllowList.UnknownFlags = true
+
+	err := tfs.parseArgs(args, func(*Flag, string) error { return nil })
+	if err != nil {
+		return nil, err
+	}
+	return tfs.tokens, nil
+}
+
+// Tokenize splits the arguments into tokens, using the command-line flags.
+func Tokenize(args []string) ([]Token, error) {
+	return CommandLine.Tokenize(args)
+}
+
+// pendingOccurrence is an occurrence of a flag in the argument being tokenized.
+type pendingOccurrence struct {
+	flag       *Flag
+	occurrence Occurrence
+}
+
+// addPositionalTokens adds a positional token for each of the arguments.
+func (fs *FlagSet) addPositionalTokens(args []string, indexes []int) {
+	if !fs.tokenizing {
+		return
+	}
+	for i, arg := range args {
+		fs.tokens = append(fs.tokens, Token{Kind: TokenPositional, Args: []string{arg}, Index: indexes[i]})
+	}
+}
+
+// addTerminatorToken adds a token for the "--" argument being tokenized.
+func (fs *FlagSet) addTerminatorToken() {
+	if !fs.tokenizing {
+		return
+	}
+	fs.tokens = append(fs.tokens, Token{Kind: TokenTerminator, Args: []string{fs.argToken}, Index: fs.argIndex})
+}
+
+// addFlagTokens adds the tokens of the flags in the argument being tokenized.
+// consumed are the following arguments consumed as value, and unknownFlags the
+// number of unknown flags before the argument was parsed.
+func (fs *FlagSet) addFlagTokens(consumed []string, unknownFlags int) {
+	if !fs.tokenizing {
+		return
+	}
+
+	for _, pending := range fs.pendingOccurrences {
+		token := Token{
+			Kind:  TokenFlag,
+			Args:  []string{fs.argToken},
+			Index: fs.argIndex,
+			Flag:  pending.flag,
+			Name:  pending.occurrence.Name,
+			Form:  pending.occurrence.Form,
+			Value: pending.occurrence.Value,
+		}
+		if token.Form.Has(FormSeparateValue) {
+			token.Args = append(token.Args, consumed...)
+			consumed = nil
+		}
+		fs.tokens = append(fs.tokens, token)
+	}
+	fs.pendingOccurrences = nil
+
+	if len(fs.unknownFlags) > unknownFlags {
+		fs.tokens = append(fs.tokens, Token{
+			Kind:  TokenUnknownFlag,
+			Args:  append([]string{fs.argToken}, consumed...),
+			Index: fs.argIndex,
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestTokenize(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("verbose", false, "usage", zflag.OptShorthand('v'), zflag.OptAddNegative())
+	f.String("name", "default", "usage", zflag.OptShorthand('n'))
+	verbose, name := f.Lookup("verbose"), f.Lookup("name")
+
+	tests := []struct {
+		name          string
+		args          []string
+		interspersed  bool
+		expected      []zflag.Token
+		expectedError string
+	}{
+		{
+			name:         "empty",
+			args:         []string{},
+			interspersed: true,
+		},
+		{
+			name:         "interleaved",
+			args:         []string{"arg1", "--name", "a", "--unknown", "value", "--no-verbose", "arg2", "-x", "--", "--name=b"},
+			interspersed: true,
+			expected: []zflag.Token{
+				{Kind: zflag.TokenPositional, Args: []string{"arg1"}, Index: 0},
+				{Kind: zflag.TokenFlag, Args: []string{"--name", "a"}, Index: 1, Flag: name, Name: "name", Form: zflag.FormLong | zflag.FormSeparateValue, Value: "a"},
+				{Kind: zflag.TokenUnknownFlag, Args: []string{"--unknown", "value"}, Index: 3},
+				{Kind: zflag.TokenFlag, Args: []string{"--no-verbose"}, Index: 5, Flag: verbose, Name: "verbose", Form: zflag.FormLong | zflag.FormNegated, Value: "false"},
+				{Kind: zflag.TokenPositional, Args: []string{"arg2"}, Index: 6},
+				{Kind: zflag.TokenUnknownFlag, Args: []string{"-x"}, Index: 7},
+				{Kind: zflag.TokenTerminator, Args: []string{"--"}, Index: 8},
+				{Kind: zflag.TokenPositional, Args: []string{"--name=b"}, Index: 9},
+			},
+		},
+		{
+			name:         "combined shorthands",
+			args:         []string{"-vn", "a", "--help"},
+			interspersed: true,
+			expected: []zflag.Token{
+				{Kind: zflag.TokenFlag, Args: []string{"-vn"}, Index: 0, Flag: verbose, Name: "v", Form: zflag.FormShorthand, Value: ""},
+				{Kind: zflag.TokenFlag, Args: []string{"-vn", "a"}, Index: 0, Flag: name, Name: "n", Form: zflag.FormShorthand | zflag.FormSeparateValue, Value: "a"},
+				{Kind: zflag.TokenUnknownFlag, Args: []string{"--help"}, Index: 2},
+			},
+		},
+		{
+			name: "not interspersed",
+			args: []string{"--name=a", "arg", "--verbose"},
+			expected: []zflag.Token{
+				{Kind: zflag.TokenFlag, Args: []string{"--name=a"}, Index: 0, Flag: name, Name: "name", Form: zflag.FormLong | zflag.FormInlineValue, Value: "a"},
+				{Kind: zflag.TokenPositional, Args: []string{"arg"}, Index: 1},
+				{Kind: zflag.TokenPositional, Args: []string{"--verbose"}, Index: 2},
+			},
+		},
+		{
+			name:          "syntax error",
+			args:          []string{"--name"},
+			interspersed:  true,
+			expectedError: "flag needs an argument: --name",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			f.SetInterspersed(test.interspersed)
+			tokens, err := f.Tokenize(test.args)
+			if test.expectedError != "" {
+				assertErrMsg(t, test.expectedError, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, tokens)
+		})
+	}
+
+	assertEqual(t, false, f.Parsed())
+	assertEqual(t, false, verbose.Changed)
+	assertEqual(t, "default", name.Value.String())
+	assertEqual(t, 0, len(name.Occurrences()))
+	assertEqual(t, 0, len(f.Args()))
+	assertEqual(t, 0, len(f.GetUnknownFlags()))
+}
@@ -9,33 +9,50 @@ import (
 )
 
 // -- uint Value
-type uintValue uint
+type uintValue struct {
+	value    *uint
+	defValue uint
+}
 
 var _ Value = (*uintValue)(nil)
 var _ Getter = (*uintValue)(nil)
 var _ Typed = (*uintValue)(nil)
+var _ Cloner = (*uintValue)(nil)
+var _ Resetter = (*uintValue)(nil)
 
 func newUintValue(val uint, p *uint) *uintValue {
 	*p = val
-	return (*uintValue)(p)
+	return &uintValue{value: p, defValue: val}
 }
 
 func (i *uintValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseUint(val, 0, 64)
-	*i = uintValue(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = uint(v)
+	return nil
 }
 
 func (i *uintValue) Get() interface{} {
-	return uint(*i)
+	return *i.value
 }
 
 func (i *uintValue) Type() string {
 	return "uint"
 }
 
-func (i *uintValue) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uintValue) Clone() Value {
+	value := *i.value
+	return &uintValue{value: &value, defValue: i.defValue}
+}
+
+func (i *uintValue) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *uintValue) String() string { return strconv.FormatUint(uint64(*i.value), 10) }
 
 // GetUint return the uint value of a flag with the given name
 func (fs *FlagSet) GetUint(name string) (uint, error) {
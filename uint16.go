@@ -9,33 +9,50 @@ import (
 )
 
 // -- uint16 value
-type uint16Value uint16
+type uint16Value struct {
+	value    *uint16
+	defValue uint16
+}
 
 var _ Value = (*uint16Value)(nil)
 var _ Getter = (*uint16Value)(nil)
 var _ Typed = (*uint16Value)(nil)
+var _ Cloner = (*uint16Value)(nil)
+var _ Resetter = (*uint16Value)(nil)
 
 func newUint16Value(val uint16, p *uint16) *uint16Value {
 	*p = val
-	return (*uint16Value)(p)
+	return &uint16Value{value: p, defValue: val}
 }
 
 func (i *uint16Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseUint(val, 0, 16)
-	*i = uint16Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = uint16(v)
+	return nil
 }
 
 func (i *uint16Value) Get() interface{} {
-	return uint16(*i)
+	return *i.value
 }
 
 func (i *uint16Value) Type() string {
 	return "uint16"
 }
 
-func (i *uint16Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uint16Value) Clone() Value {
+	value := *i.value
+	return &uint16Value{value: &value, defValue: i.defValue}
+}
+
+func (i *uint16Value) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *uint16Value) String() string { return strconv.FormatUint(uint64(*i.value), 10) }
 
 // GetUint16 return the uint16 value of a flag with the given name
 func (fs *FlagSet) GetUint16(name string) (uint16, error) {
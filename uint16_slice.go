@@ -11,19 +11,23 @@ import (
 
 // -- uint16Slice Value
 type uint16SliceValue struct {
-	value   *[]uint16
-	changed bool
+	value    *[]uint16
+	changed  bool
+	defaults []uint16
 }
 
 var _ Value = (*uint16SliceValue)(nil)
 var _ Getter = (*uint16SliceValue)(nil)
 var _ SliceValue = (*uint16SliceValue)(nil)
 var _ Typed = (*uint16SliceValue)(nil)
+var _ Cloner = (*uint16SliceValue)(nil)
+var _ Resetter = (*uint16SliceValue)(nil)
 
 func newUint16SliceValue(val []uint16, p *[]uint16) *uint16SliceValue {
 	isv := new(uint16SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *uint16SliceValue) Type() string {
 	return "uint16Slice"
 }
 
+func (s *uint16SliceValue) Clone() Value {
+	value := append([]uint16(nil), *s.value...)
+	return &uint16SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *uint16SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *uint16SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
@@ -9,33 +9,50 @@ import (
 )
 
 // -- uint32 value
-type uint32Value uint32
+type uint32Value struct {
+	value    *uint32
+	defValue uint32
+}
 
 var _ Value = (*uint32Value)(nil)
 var _ Getter = (*uint32Value)(nil)
 var _ Typed = (*uint32Value)(nil)
+var _ Cloner = (*uint32Value)(nil)
+var _ Resetter = (*uint32Value)(nil)
 
 func newUint32Value(val uint32, p *uint32) *uint32Value {
 	*p = val
-	return (*uint32Value)(p)
+	return &uint32Value{value: p, defValue: val}
 }
 
 func (i *uint32Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseUint(val, 0, 32)
-	*i = uint32Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = uint32(v)
+	return nil
 }
 
 func (i *uint32Value) Get() interface{} {
-	return uint32(*i)
+	return *i.value
 }
 
 func (i *uint32Value) Type() string {
 	return "uint32"
 }
 
-func (i *uint32Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uint32Value) Clone() Value {
+	value := *i.value
+	return &uint32Value{value: &value, defValue: i.defValue}
+}
+
+func (i *uint32Value) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *uint32Value) String() string { return strconv.FormatUint(uint64(*i.value), 10) }
 
 // GetUint32 return the uint32 value of a flag with the given name
 func (fs *FlagSet) GetUint32(name string) (uint32, error) {
@@ -11,19 +11,23 @@ import (
 
 // -- uint32Slice Value
 type uint32SliceValue struct {
-	value   *[]uint32
-	changed bool
+	value    *[]uint32
+	changed  bool
+	defaults []uint32
 }
 
 var _ Value = (*uint32SliceValue)(nil)
 var _ Getter = (*uint32SliceValue)(nil)
 var _ SliceValue = (*uint32SliceValue)(nil)
 var _ Typed = (*uint32SliceValue)(nil)
+var _ Cloner = (*uint32SliceValue)(nil)
+var _ Resetter = (*uint32SliceValue)(nil)
 
 func newUint32SliceValue(val []uint32, p *[]uint32) *uint32SliceValue {
 	isv := new(uint32SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *uint32SliceValue) Type() string {
 	return "uint32Slice"
 }
 
+func (s *uint32SliceValue) Clone() Value {
+	value := append([]uint32(nil), *s.value...)
+	return &uint32SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *uint32SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *uint32SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
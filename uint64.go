@@ -9,33 +9,50 @@ import (
 )
 
 // -- uint64 Value
-type uint64Value uint64
+type uint64Value struct {
+	value    *uint64
+	defValue uint64
+}
 
 var _ Value = (*uint64Value)(nil)
 var _ Getter = (*uint64Value)(nil)
 var _ Typed = (*uint64Value)(nil)
+var _ Cloner = (*uint64Value)(nil)
+var _ Resetter = (*uint64Value)(nil)
 
 func newUint64Value(val uint64, p *uint64) *uint64Value {
 	*p = val
-	return (*uint64Value)(p)
+	return &uint64Value{value: p, defValue: val}
 }
 
 func (i *uint64Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseUint(val, 0, 64)
-	*i = uint64Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = v
+	return nil
 }
 
 func (i *uint64Value) Get() interface{} {
-	return uint64(*i)
+	return *i.value
 }
 
 func (i *uint64Value) Type() string {
 	return "uint64"
 }
 
-func (i *uint64Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uint64Value) Clone() Value {
+	value := *i.value
+	return &uint64Value{value: &value, defValue: i.defValue}
+}
+
+func (i *uint64Value) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *uint64Value) String() string { return strconv.FormatUint(*i.value, 10) }
 
 // GetUint64 return the uint64 value of a flag with the given name
 func (fs *FlagSet) GetUint64(name string) (uint64, error) {
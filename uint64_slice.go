@@ -11,19 +11,23 @@ import (
 
 // -- uint64Slice Value
 type uint64SliceValue struct {
-	value   *[]uint64
-	changed bool
+	value    *[]uint64
+	changed  bool
+	defaults []uint64
 }
 
 var _ Value = (*uint64SliceValue)(nil)
 var _ Getter = (*uint64SliceValue)(nil)
 var _ SliceValue = (*uint64SliceValue)(nil)
 var _ Typed = (*uint64SliceValue)(nil)
+var _ Cloner = (*uint64SliceValue)(nil)
+var _ Resetter = (*uint64SliceValue)(nil)
 
 func newUint64SliceValue(val []uint64, p *[]uint64) *uint64SliceValue {
 	isv := new(uint64SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *uint64SliceValue) Type() string {
 	return "uint64Slice"
 }
 
+func (s *uint64SliceValue) Clone() Value {
+	value := append([]uint64(nil), *s.value...)
+	return &uint64SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *uint64SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *uint64SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
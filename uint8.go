@@ -9,33 +9,50 @@ import (
 )
 
 // -- uint8 Value
-type uint8Value uint8
+type uint8Value struct {
+	value    *uint8
+	defValue uint8
+}
 
 var _ Value = (*uint8Value)(nil)
 var _ Getter = (*uint8Value)(nil)
 var _ Typed = (*uint8Value)(nil)
+var _ Cloner = (*uint8Value)(nil)
+var _ Resetter = (*uint8Value)(nil)
 
 func newUint8Value(val uint8, p *uint8) *uint8Value {
 	*p = val
-	return (*uint8Value)(p)
+	return &uint8Value{value: p, defValue: val}
 }
 
 func (i *uint8Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseUint(val, 0, 8)
-	*i = uint8Value(v)
-	return err
+	if err != nil {
+		return err
+	}
+	*i.value = uint8(v)
+	return nil
 }
 
 func (i *uint8Value) Get() interface{} {
-	return uint8(*i)
+	return *i.value
 }
 
 func (i *uint8Value) Type() string {
 	return "uint8"
 }
 
-func (i *uint8Value) String() string { return strconv.FormatUint(uint64(*i), 10) }
+func (i *uint8Value) Clone() Value {
+	value := *i.value
+	return &uint8Value{value: &value, defValue: i.defValue}
+}
+
+func (i *uint8Value) Reset() {
+	*i.value = i.defValue
+}
+
+func (i *uint8Value) String() string { return strconv.FormatUint(uint64(*i.value), 10) }
 
 // GetUint8 return the uint8 value of a flag with the given name
 func (fs *FlagSet) GetUint8(name string) (uint8, error) {
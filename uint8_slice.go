@@ -11,19 +11,23 @@ import (
 
 // -- uint8Slice Value
 type uint8SliceValue struct {
-	value   *[]uint8
-	changed bool
+	value    *[]uint8
+	changed  bool
+	defaults []uint8
 }
 
 var _ Value = (*uint8SliceValue)(nil)
 var _ Getter = (*uint8SliceValue)(nil)
 var _ SliceValue = (*uint8SliceValue)(nil)
 var _ Typed = (*uint8SliceValue)(nil)
+var _ Cloner = (*uint8SliceValue)(nil)
+var _ Resetter = (*uint8SliceValue)(nil)
 
 func newUint8SliceValue(val []uint8, p *[]uint8) *uint8SliceValue {
 	isv := new(uint8SliceValue)
 	isv.value = p
 	*isv.value = val
+	isv.defaults = val
 	return isv
 }
 
@@ -51,6 +55,16 @@ func (s *uint8SliceValue) Type() string {
 	return "uint8Slice"
 }
 
+func (s *uint8SliceValue) Clone() Value {
+	value := append([]uint8(nil), *s.value...)
+	return &uint8SliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *uint8SliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *uint8SliceValue) String() string {
 	if s.value == nil {
 		return "[]"
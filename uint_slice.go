@@ -11,19 +11,23 @@ import (
 
 // -- uintSlice Value
 type uintSliceValue struct {
-	value   *[]uint
-	changed bool
+	value    *[]uint
+	changed  bool
+	defaults []uint
 }
 
 var _ Value = (*uintSliceValue)(nil)
 var _ Getter = (*uintSliceValue)(nil)
 var _ SliceValue = (*uintSliceValue)(nil)
 var _ Typed = (*uintSliceValue)(nil)
+var _ Cloner = (*uintSliceValue)(nil)
+var _ Resetter = (*uintSliceValue)(nil)
 
 func newUintSliceValue(val []uint, p *[]uint) *uintSliceValue {
 	uisv := new(uintSliceValue)
 	uisv.value = p
 	*uisv.value = val
+	uisv.defaults = val
 	return uisv
 }
 
@@ -51,6 +55,16 @@ func (s *uintSliceValue) Type() string {
 	return "uintSlice"
 }
 
+func (s *uintSliceValue) Clone() Value {
+	value := append([]uint(nil), *s.value...)
+	return &uintSliceValue{value: &value, changed: s.changed, defaults: s.defaults}
+}
+
+func (s *uintSliceValue) Reset() {
+	*s.value = s.defaults
+	s.changed = false
+}
+
 func (s *uintSliceValue) String() string {
 	if s.value == nil {
 		return "[]"
@@ -0,0 +1,260 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition is a condition on the flags of a FlagSet, used by RequiredIf and
+// RequiredUnless.
+type Condition interface {
+	// Match returns whether the condition is met.
+	Match(fs *FlagSet) bool
+	// String describes the condition, e.g. `--tls is set`.
+	String() string
+}
+
+type flagIsSetCondition struct {
+	name string
+}
+
+var _ Condition = (*flagIsSetCondition)(nil)
+
+// FlagIsSet returns a Condition which is met when the named flag is set, on the
+// command line or from any other source.
+func FlagIsSet(name string) Condition {
+	return flagIsSetCondition{name: name}
+}
+
+func (c flagIsSetCondition) Match(fs *FlagSet) bool {
+	flag := fs.Lookup(c.name)
+	return flag != nil && flag.isSet()
+}
+
+func (c flagIsSetCondition) String() string {
+	return getFlagWithDashes(c.name) + " is set"
+}
+
+type flagEqualsCondition struct {
+	name  string
+	value string
+}
+
+var _ Condition = (*flagEqualsCondition)(nil)
+
+// FlagEquals returns a Condition which is met when the string representation
+// of the value of the named flag equals value.
+func FlagEquals(name, value string) Condition {
+	return flagEqualsCondition{name: name, value: value}
+}
+
+func (c flagEqualsCondition) Match(fs *FlagSet) bool {
+	flag := fs.Lookup(c.name)
+	return flag != nil && flag.Value.String() == c.value
+}
+
+func (c flagEqualsCondition) String() string {
+	return fmt.Sprintf("%s is %q", getFlagWithDashes(c.name), c.value)
+}
+
+// conditionalRequirement requires flags depending on a condition.
+type conditionalRequirement struct {
+	condition Condition
+	unless    bool
+	flags     []*Flag
+}
+
+// RequiredIf marks the named flags as required when the condition is met,
+// e.g. RequiredIf(FlagIsSet("tls"), "cert", "key"). It panics if one of the
+// flags does not exist.
+func (fs *FlagSet) RequiredIf(condition Condition, names ...string) {
+	fs.addRequirement(condition, false, names)
+}
+
+// RequiredIf marks the named command-line flags as required when the condition is met.
+func RequiredIf(condition Condition, names ...string) {
+	CommandLine.RequiredIf(condition, names...)
+}
+
+// RequiredUnless marks the named flags as required unless the condition is
+// met, e.g. RequiredUnless(FlagEquals("mode", "standalone"), "primary"). It
+// panics if one of the flags does not exist.
+func (fs *FlagSet) RequiredUnless(condition Condition, names ...string) {
+	fs.addRequirement(condition, true, names)
+}
+
+// RequiredUnless marks the named command-line flags as required unless the condition is met.
+func RequiredUnless(condition Condition, names ...string) {
+	CommandLine.RequiredUnless(condition, names...)
+}
+
+func (fs *FlagSet) addRequirement(condition Condition, unless bool, names []string) {
+	fs.checkNotFrozen("add a requirement")
+	requirement := conditionalRequirement{condition: condition, unless: unless}
+	for _, name := range names {
+		flag := fs.Lookup(name)
+		if flag == nil {
+			msg := fmt.Sprintf("unable to require %q in %q flagset: flag does not exist", name, fs.name)
+			fmt.Fprintln(fs.Output(), msg)
+			panic(msg)
+		}
+		requirement.flags = append(requirement.flags, flag)
+	}
+
+	fs.requirements = append(fs.requirements, requirement)
+}
+
+// validateConditionalRequirements returns an error for every conditional
+// requirement which is not met.
+func (fs *FlagSet) validateConditionalRequirements() []error {
+	if fs.ParseErrorsAllowList.RequiredFlags {
+		return nil
+	}
+
+	var errs []error
+	for _, requirement := range fs.requirements {
+		if requirement.condition.Match(fs) == requirement.unless {
+			continue
+		}
+
+		var missing []string
+		for _, flag := range requirement.flags {
+			if !flag.isSet() {
+				missing = append(missing, getFlagWithDashes(flag.Name))
+			}
+		}
+		if len(missing) > 0 {
+			errs = append(errs, ConditionallyRequiredFlagsError{
+				Missing:   missing,
+				Condition: requirement.condition,
+				Unless:    requirement.unless,
+			})
+		}
+	}
+
+	return errs
+}
+
+// AddValidator adds a function which validates the flags of the FlagSet. The
+// validators are run by Validate, in the order they were added, after the
+// required flags, flag groups and conditional requirements are checked.
+func (fs *FlagSet) AddValidator(validator func(fs *FlagSet) error) {
+	fs.checkNotFrozen("add a validator")
+	fs.validators = append(fs.validators, validator)
+}
+
+// AddValidator adds a function which validates the command-line flags.
+func AddValidator(validator func(fs *FlagSet) error) {
+	CommandLine.AddValidator(validator)
+}
+
+// setValue calls set with the value of the flag, and validates the new value.
+// A rejected value does not change the flag: when the value implements
+// Cloner, set is first called on a copy which is validated, or else the
+// previous value is restored. The FlagSet is locked while the value is read
+// or changed, but not while it is validated, so that validators can read the
+// values of other flags.
+func (fs *FlagSet) setValue(flag *Flag, set func(v Value) error) error {
+	if len(flag.Choices) == 0 && len(flag.Validators) == 0 {
+		defer fs.lock()()
+		return set(flag.Value)
+	}
+
+	// the value of a Func flag cannot be copied or restored, as setting it
+	// calls the function.
+	if _, isFunc := flag.Value.(*funcValue); isFunc {
+		unlock := fs.lock()
+		err := set(flag.Value)
+		unlock()
+		if err != nil {
+			return err
+		}
+		return flag.validate(flag.Value)
+	}
+
+	if cloner, ok := flag.Value.(Cloner); ok {
+		unlock := fs.rlock()
+		clone := cloner.Clone()
+		unlock()
+		if err := set(clone); err != nil {
+			return err
+		}
+		if err := flag.validate(clone); err != nil {
+			return err
+		}
+		defer fs.lock()()
+		return set(flag.Value)
+	}
+
+	unlock := fs.lock()
+	restore := flag.snapshot()
+	err := set(flag.Value)
+	unlock()
+	if err != nil {
+		return err
+	}
+	if err := flag.validate(flag.Value); err != nil {
+		defer fs.lock()()
+		restore()
+		return err
+	}
+	return nil
+}
+
+// snapshot returns a function restoring the current value of the flag.
+func (f *Flag) snapshot() func() {
+	if sv, ok := f.Value.(SliceValue); ok {
+		values := sv.GetSlice()
+		return func() { _ = sv.Replace(values) }
+	}
+	value := f.Value.String()
+	return func() { _ = f.Value.Set(value) }
+}
+
+// validate checks the value against the choices and validators of the flag.
+func (f *Flag) validate(value Value) error {
+	if len(f.Choices) > 0 {
+		values := []string{value.String()}
+		if sv, ok := value.(SliceValue); ok {
+			values = sv.GetSlice()
+		}
+		for _, v := range values {
+			if !f.isChoice(v) {
+				return fmt.Errorf("must be one of: %s", strings.Join(f.Choices, ", "))
+			}
+		}
+	}
+
+	if len(f.Validators) > 0 {
+		var v interface{} = value.String()
+		if getter, ok := value.(Getter); ok {
+			v = getter.Get()
+		}
+		for _, validator := range f.Validators {
+			if err := validator(v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isChoice returns whether value is one of the choices of the flag.
+func (f *Flag) isChoice(value string) bool {
+	for _, choice := range f.Choices {
+		if choice == value {
+			return true
+		}
+	}
+	return false
+}
+
+// isSet returns whether the flag was set on the command line or from any
+// other source than its default value.
+func (f *Flag) isSet() bool {
+	return f.Changed || f.Source() != SourceDefault
+}
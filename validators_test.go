@@ -0,0 +1,268 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestConditionalRequirements(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		allowList   zflag.ParseErrorsAllowList
+		expectedErr string
+	}{
+		{
+			name: "conditions not met",
+			args: []string{"--data-dir=/tmp"},
+		},
+		{
+			name: "unless condition met",
+			args: []string{"--mode=memory"},
+		},
+		{
+			name:        "required if set",
+			args:        []string{"--tls", "--cert=a", "--data-dir=/tmp"},
+			expectedErr: `required flag(s) "--key" not set, they are required when --tls is set`,
+		},
+		{
+			name:        "required if equals",
+			args:        []string{"--mode=replica", "--data-dir=/tmp"},
+			expectedErr: `required flag(s) "--primary" not set, they are required when --mode is "replica"`,
+		},
+		{
+			name:        "required unless",
+			args:        []string{},
+			expectedErr: `required flag(s) "--data-dir" not set, they are required unless --mode is "memory"`,
+		},
+		{
+			name:        "aggregated",
+			args:        []string{"--tls", "--mode=replica"},
+			expectedErr: `required flag(s) "--cert", "--key" not set, they are required when --tls is set; required flag(s) "--primary" not set, they are required when --mode is "replica"; required flag(s) "--data-dir" not set, they are required unless --mode is "memory"`,
+		},
+		{
+			name:      "required flags allowed",
+			args:      []string{"--tls"},
+			allowList: zflag.ParseErrorsAllowList{RequiredFlags: true},
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.ParseErrorsAllowList = test.allowList
+			f.Bool("tls", false, "usage")
+			f.String("cert", "", "usage")
+			f.String("key", "", "usage")
+			f.String("mode", "primary", "usage")
+			f.String("primary", "", "usage")
+			f.String("data-dir", "", "usage")
+			f.RequiredIf(zflag.FlagIsSet("tls"), "cert", "key")
+			f.RequiredIf(zflag.FlagEquals("mode", "replica"), "primary")
+			f.RequiredUnless(zflag.FlagEquals("mode", "memory"), "data-dir")
+			err := f.Parse(test.args)
+			if test.expectedErr == "" {
+				assertNoErr(t, err)
+				return
+			}
+			assertErrMsg(t, test.expectedErr, err)
+		})
+	}
+}
+
+func TestValidators(t *testing.T) {
+	errPort := errors.New("--port must be above 1024 when not running as root")
+	newFlagSet := func() *zflag.FlagSet {
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		f.SetOutput(ioutil.Discard)
+		port := f.Int("port", 8080, "usage")
+		f.String("name", "", "usage", zflag.OptRequired())
+		f.AddValidator(func(fs *zflag.FlagSet) error {
+			if *port <= 1024 {
+				return errPort
+			}
+			return nil
+		})
+		return f
+	}
+
+	assertNoErr(t, newFlagSet().Parse([]string{"--name=a"}))
+	assertEqual(t, errPort, newFlagSet().Parse([]string{"--name=a", "--port=80"}))
+
+	err := newFlagSet().Parse([]string{"--port=80"})
+	assertErrMsg(t, `required flag(s) "--name" not set; --port must be above 1024 when not running as root`, err)
+
+	var validationErrs zflag.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("expected ValidationErrors, got %T", err)
+	}
+	assertEqual(t, 2, len(validationErrs))
+	var missingErr zflag.MissingFlagsError
+	if !errors.As(validationErrs[0], &missingErr) {
+		t.Errorf("expected a MissingFlagsError, got %T", validationErrs[0])
+	}
+	assertEqual(t, errPort, validationErrs[1])
+	found := false
+	for _, e := range validationErrs {
+		found = found || errors.Is(e, errPort)
+	}
+	if !found {
+		t.Errorf("expected the validator error in %v", validationErrs)
+	}
+}
+
+func TestConditionallyRequiredFlagsError(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("tls", false, "usage")
+	f.String("cert", "", "usage")
+	f.String("key", "", "usage")
+	f.RequiredIf(zflag.FlagIsSet("tls"), "cert", "key")
+	err := f.Parse([]string{"--tls"})
+
+	var requiredErr zflag.ConditionallyRequiredFlagsError
+	if !errors.As(err, &requiredErr) {
+		t.Fatalf("expected a ConditionallyRequiredFlagsError, got %T", err)
+	}
+	assertDeepEqual(t, []string{"--cert", "--key"}, requiredErr.Missing)
+	assertEqual(t, "--tls is set", requiredErr.Condition.String())
+	assertEqual(t, false, requiredErr.Unless)
+}
+
+func TestRequiredIfUnknownFlag(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	defer assertPanic(t)()
+	f.RequiredIf(zflag.FlagIsSet("tls"), "cert")
+}
+
+func TestFlagValidators(t *testing.T) {
+	errRange := errors.New("must be between 1 and 65535")
+	validatePort := func(value interface{}) error {
+		if port := value.(int); port < 1 || port > 65535 {
+			return errRange
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			args: []string{"--level=warn", "--port=443", "--tags=a", "--tags=b"},
+		},
+		{
+			name:        "invalid choice",
+			args:        []string{"--level=trace"},
+			expectedErr: `invalid argument "trace" for "--level" flag: must be one of: debug, info, warn`,
+		},
+		{
+			name:        "invalid slice choice",
+			args:        []string{"--tags=a", "--tags=c"},
+			expectedErr: `invalid argument "c" for "--tags" flag: must be one of: a, b`,
+		},
+		{
+			name:        "validator",
+			args:        []string{"--port", "0"},
+			expectedErr: `invalid argument "0" for "--port" flag: must be between 1 and 65535`,
+		},
+		{
+			name:        "invalid value is not validated",
+			args:        []string{"--port=x"},
+			expectedErr: `invalid argument "x" for "--port" flag: strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.String("level", "info", "usage", zflag.OptChoices("debug", "info", "warn"))
+			f.Int("port", 80, "usage", zflag.OptValidate(validatePort))
+			f.StringSlice("tags", nil, "usage", zflag.OptChoices("a", "b"))
+
+			err := f.Parse(test.args)
+			if test.expectedErr == "" {
+				assertNoErr(t, err)
+				return
+			}
+			assertErrMsg(t, test.expectedErr, err)
+
+			var invalidErr zflag.InvalidArgumentError
+			if !errors.As(err, &invalidErr) {
+				t.Errorf("expected an InvalidArgumentError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestFlagValidatorsSources(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("level", "info", "usage", zflag.OptChoices("debug", "info"))
+
+	assertErrMsg(t, `invalid argument "trace" for "--level" flag: must be one of: debug, info`, f.Set("level", "trace"))
+
+	err := f.LoadConfig(strings.NewReader("level: trace"), zflag.ConfigYAML)
+	assertErrMsg(t, `config key "level": invalid argument "trace" for "--level" flag: must be one of: debug, info`, err)
+}
+
+func TestFlagValidatorsRejectedValue(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.CollectErrors = true
+	level := f.String("level", "info", "usage", zflag.OptChoices("debug", "info"))
+	port := f.Int("port", 80, "usage", zflag.OptValidate(func(value interface{}) error {
+		if value.(int) < 0 {
+			return errors.New("must not be negative")
+		}
+		return nil
+	}))
+	tags := f.StringSlice("tags", nil, "usage", zflag.OptChoices("a", "b"))
+	labels := f.StringToString("labels", nil, "usage", zflag.OptValidate(func(value interface{}) error {
+		if _, ok := value.(map[string]string)["bad"]; ok {
+			return errors.New("bad label")
+		}
+		return nil
+	}))
+	custom := new(customValue)
+	f.Var(custom, "custom", "usage", zflag.OptChoices("1", "2"))
+
+	assertErr(t, f.Set("level", "bogus"))
+	assertEqual(t, "info", *level)
+	assertEqual(t, false, f.Changed("level"))
+
+	assertErr(t, f.Parse([]string{"--port=-1", "--tags=a", "--tags=c", "--custom=1", "--custom=3"}))
+	assertEqual(t, 80, *port)
+	assertDeepEqual(t, []string{"a"}, *tags)
+	assertEqual(t, customValue(1), *custom)
+
+	assertErr(t, f.LoadConfig(strings.NewReader("labels:\n  app: web\n  bad: x"), zflag.ConfigYAML))
+	assertDeepEqual(t, map[string]string{"app": "web"}, *labels)
+}
+
+func TestChoicesUsage(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("level", "info", "log level", zflag.OptChoices("debug", "info", "warn"))
+
+	assertEqual(t, `      --level string   log level (one of: debug, info, warn) (default "info")`+"\n", f.FlagUsages())
+}